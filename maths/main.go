@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"encoding/json"
 
 	"github.com/gorilla/mux"
@@ -17,12 +20,94 @@ const port = 8338
 
 type Data struct {
 	Nums      []float64  `json:"nums"`
-	Qualifier int        `json:"qualifier,omitempty"`
+	Qualifier float64    `json:"qualifier,omitempty"`
+	Sample    bool       `json:"sample,omitempty"`
+	Method    string     `json:"method,omitempty"`
+	Percentiles []float64 `json:"percentiles,omitempty"`
+	Bins      string     `json:"bins,omitempty"`
+	Width     float64    `json:"width,omitempty"`
+	Edges     []float64  `json:"edges,omitempty"`
+}
+
+// count - the qualifier as a number of values to return, for min and max
+func (d *Data) count() (int, error) {
+	if d.Qualifier != math.Trunc(d.Qualifier) {
+		return 0, fmt.Errorf("%w: count %v is not a whole number", maths.ErrQualifierOutOfRange, d.Qualifier)
+	}
+	return int(d.Qualifier), nil
+}
+
+// toQuantile - convert a percentile (0 to 100) to a quantile (0 to 1)
+func toQuantile(pct float64) (float64, error) {
+	if !(pct >= 0 && pct <= 100) {
+		return 0, fmt.Errorf("%w: percentile %v is not between 0 and 100", maths.ErrQualifierOutOfRange, pct)
+	}
+	return pct / 100, nil
+}
+
+// quantile - the qualifier as a percentile, converted to the quantile and
+//  method for maths.Quantile
+func (d *Data) quantile() (float64, maths.QuantileMethod, error) {
+	method, err := maths.ParseQuantileMethod(d.Method)
+	if err != nil {
+		return 0, method, err
+	}
+	p, err := toQuantile(d.Qualifier)
+	return p, method, err
+}
+
+// quantiles - the percentiles, converted to the quantiles and method for
+//  maths.Quantiles
+func (d *Data) quantiles() ([]float64, maths.QuantileMethod, error) {
+	method, err := maths.ParseQuantileMethod(d.Method)
+	if err != nil {
+		return nil, method, err
+	}
+	if len(d.Percentiles) == 0 {
+		return nil, method, fmt.Errorf("%w: no percentiles requested", maths.ErrQualifierOutOfRange)
+	}
+
+	ps := make([]float64, len(d.Percentiles))
+	for i, pct := range d.Percentiles {
+		if ps[i], err = toQuantile(pct); err != nil {
+			return nil, method, err
+		}
+	}
+	return ps, method, nil
+}
+
+// edges - the histogram bin edges, either as given, of the given width, or
+//  chosen by the named rule
+func (d *Data) edges() ([]float64, error) {
+	if len(d.Nums) == 0 {
+		return nil, maths.ErrEmptyDataset
+	}
+	if len(d.Edges) > 0 {
+		return d.Edges, nil
+	}
+	if d.Width != 0 {
+		return maths.FixedWidthEdges(d.Nums, d.Width)
+	}
+	rule, err := maths.ParseBinRule(d.Bins)
+	if err != nil {
+		return nil, err
+	}
+	return maths.Edges(d.Nums, rule)
+}
+
+// estimator - whether the data is a sample, or the whole population
+func (d *Data) estimator() maths.Estimator {
+	if d.Sample {
+		return maths.Sample
+	}
+	return maths.Population
 }
 
 type Response struct {
 	Answer  float64   `json:"answer,omitempty"`
 	Answers []float64 `json:"answers,omitempty"`
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+	Histogram []maths.Bin `json:"histogram,omitempty"`
 }
 
 func main() {
@@ -63,6 +148,24 @@ func parseResponse(answer *float64, answers []float64) ([]byte, error) {
 	return json.Marshal(ans)
 }
 
+// errorStatus - the response status for an error from the maths package,
+//  invalid input is the client's fault, anything else is ours
+func errorStatus(err error) int {
+	for _, inputErr := range []error{
+		maths.ErrEmptyDataset,
+		maths.ErrQualifierOutOfRange,
+		maths.ErrInsufficientData,
+		maths.ErrUnknownMethod,
+		maths.ErrNonFinite,
+		maths.ErrInvalidBins,
+	} {
+		if errors.Is(err, inputErr) {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
+
 // minHandler - handle min request
 func minHandler(w http.ResponseWriter, r *http.Request) {
 	data, err := parseRequest(r)
@@ -73,7 +176,18 @@ func minHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	answers := maths.Min(data.Nums, data.Qualifier)
+	var answers []float64
+	count, err := data.count()
+	if err == nil {
+		answers, err = maths.MinErr(data.Nums, count)
+	}
+	if err != nil {
+		log.Printf("failed calculating min: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
 	resp, err := parseResponse(nil, answers)
 	if err != nil {
 		log.Printf("failed parsing response: %+v", err)
@@ -96,7 +210,18 @@ func maxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	answers := maths.Max(data.Nums, data.Qualifier)
+	var answers []float64
+	count, err := data.count()
+	if err == nil {
+		answers, err = maths.MaxErr(data.Nums, count)
+	}
+	if err != nil {
+		log.Printf("failed calculating max: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
 	resp, err := parseResponse(nil, answers)
 	if err != nil {
 		log.Printf("failed parsing response: %+v", err)
@@ -119,7 +244,14 @@ func avgHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	answer := maths.Avg(data.Nums)
+	answer, err := maths.AvgErr(data.Nums)
+	if err != nil {
+		log.Printf("failed calculating avg: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
 	resp, err := parseResponse(&answer, nil)
 	if err != nil {
 		log.Printf("failed parsing response: %+v", err)
@@ -142,7 +274,14 @@ func medianHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	answer := maths.Median(data.Nums)
+	answer, err := maths.MedianErr(data.Nums)
+	if err != nil {
+		log.Printf("failed calculating median: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
 	resp, err := parseResponse(&answer, nil)
 	if err != nil {
 		log.Printf("failed parsing response: %+v", err)
@@ -165,7 +304,18 @@ func percentileHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	answer := maths.Percentile(data.Nums, data.Qualifier)
+	var answer float64
+	p, method, err := data.quantile()
+	if err == nil {
+		answer, err = maths.Quantile(data.Nums, p, method)
+	}
+	if err != nil {
+		log.Printf("failed calculating percentile: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
 	resp, err := parseResponse(&answer, nil)
 	if err != nil {
 		log.Printf("failed parsing response: %+v", err)
@@ -178,6 +328,169 @@ func percentileHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(resp)
 }
 
+// quantilesHandler - handle quantiles request
+func quantilesHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := parseRequest(r)
+	if err != nil {
+		log.Printf("failed parsing request: %+v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad request"))
+		return
+	}
+
+	var answers []float64
+	ps, method, err := data.quantiles()
+	if err == nil {
+		answers, err = maths.Quantiles(data.Nums, ps, method)
+	}
+	if err != nil {
+		log.Printf("failed calculating quantiles: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	// key each answer by the percentile as it was asked for
+	quantiles := make(map[string]float64, len(answers))
+	for i, pct := range data.Percentiles {
+		quantiles[strconv.FormatFloat(pct, 'f', -1, 64)] = answers[i]
+	}
+	resp, err := json.Marshal(&Response{Quantiles: quantiles})
+	if err != nil {
+		log.Printf("failed parsing response: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error setting response"))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// varianceHandler - handle variance request
+func varianceHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := parseRequest(r)
+	if err != nil {
+		log.Printf("failed parsing request: %+v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad request"))
+		return
+	}
+
+	answer, err := maths.Variance(data.Nums, data.estimator())
+	if err != nil {
+		log.Printf("failed calculating variance: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	resp, err := parseResponse(&answer, nil)
+	if err != nil {
+		log.Printf("failed parsing response: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error setting response"))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// stddevHandler - handle stddev request
+func stddevHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := parseRequest(r)
+	if err != nil {
+		log.Printf("failed parsing request: %+v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad request"))
+		return
+	}
+
+	answer, err := maths.StdDev(data.Nums, data.estimator())
+	if err != nil {
+		log.Printf("failed calculating stddev: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	resp, err := parseResponse(&answer, nil)
+	if err != nil {
+		log.Printf("failed parsing response: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error setting response"))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// modeHandler - handle mode request
+func modeHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := parseRequest(r)
+	if err != nil {
+		log.Printf("failed parsing request: %+v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad request"))
+		return
+	}
+
+	answers, err := maths.Mode(data.Nums)
+	if err != nil {
+		log.Printf("failed calculating mode: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	resp, err := parseResponse(nil, answers)
+	if err != nil {
+		log.Printf("failed parsing response: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error setting response"))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// histogramHandler - handle histogram request
+func histogramHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := parseRequest(r)
+	if err != nil {
+		log.Printf("failed parsing request: %+v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad request"))
+		return
+	}
+
+	var bins []maths.Bin
+	edges, err := data.edges()
+	if err == nil {
+		bins, err = maths.Histogram(data.Nums, edges)
+	}
+	if err != nil {
+		log.Printf("failed calculating histogram: %+v", err)
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	resp, err := json.Marshal(&Response{Histogram: bins})
+	if err != nil {
+		log.Printf("failed parsing response: %+v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error setting response"))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
 // reqister the endpoint handlers
 func registerHandlers() *mux.Router {
 	r := mux.NewRouter()
@@ -186,6 +499,11 @@ func registerHandlers() *mux.Router {
 	r.HandleFunc("/avg", avgHandler)
 	r.HandleFunc("/median", medianHandler)
 	r.HandleFunc("/percentile", percentileHandler)
+	r.HandleFunc("/quantiles", quantilesHandler)
+	r.HandleFunc("/variance", varianceHandler)
+	r.HandleFunc("/stddev", stddevHandler)
+	r.HandleFunc("/mode", modeHandler)
+	r.HandleFunc("/histogram", histogramHandler)
 
 	return r
 }
@@ -50,6 +50,150 @@ func TestHandler(t *testing.T) {
 			hndlr:  percentileHandler,
 			status: http.StatusOK,
 		},
+		"percentile handler, fractional with method": {
+			url:    "/percentile",
+			req:    &Data{Qualifier: 99.9, Method: "linear", Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  percentileHandler,
+			status: http.StatusOK,
+		},
+		"quantiles handler": {
+			url:    "/quantiles",
+			req:    &Data{Percentiles: []float64{50,90,99.9}, Method: "linear", Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  quantilesHandler,
+			status: http.StatusOK,
+		},
+		"variance handler": {
+			url:    "/variance",
+			req:    &Data{Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  varianceHandler,
+			status: http.StatusOK,
+		},
+		"stddev handler": {
+			url:    "/stddev",
+			req:    &Data{Sample: true, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  stddevHandler,
+			status: http.StatusOK,
+		},
+		"mode handler": {
+			url:    "/mode",
+			req:    &Data{Nums: []float64{9,8,7,6,5,4,3,2,1,1,2}},
+			hndlr:  modeHandler,
+			status: http.StatusOK,
+		},
+		"histogram handler, rule": {
+			url:    "/histogram",
+			req:    &Data{Bins: "fd", Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  histogramHandler,
+			status: http.StatusOK,
+		},
+		"histogram handler, width": {
+			url:    "/histogram",
+			req:    &Data{Width: 2, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  histogramHandler,
+			status: http.StatusOK,
+		},
+		"histogram handler, edges": {
+			url:    "/histogram",
+			req:    &Data{Edges: []float64{0,5,10}, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  histogramHandler,
+			status: http.StatusOK,
+		},
+		"min handler, empty dataset": {
+			url:    "/min",
+			req:    &Data{Qualifier: 3, Nums: []float64{}},
+			hndlr:  minHandler,
+			status: http.StatusBadRequest,
+		},
+		"max handler, negative qualifier": {
+			url:    "/max",
+			req:    &Data{Qualifier: -3, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  maxHandler,
+			status: http.StatusBadRequest,
+		},
+		"avg handler, empty dataset": {
+			url:    "/avg",
+			req:    &Data{},
+			hndlr:  avgHandler,
+			status: http.StatusBadRequest,
+		},
+		"median handler, empty dataset": {
+			url:    "/median",
+			req:    &Data{},
+			hndlr:  medianHandler,
+			status: http.StatusBadRequest,
+		},
+		"percentile handler, empty dataset": {
+			url:    "/percentile",
+			req:    &Data{Qualifier: 80},
+			hndlr:  percentileHandler,
+			status: http.StatusBadRequest,
+		},
+		"min handler, fractional qualifier": {
+			url:    "/min",
+			req:    &Data{Qualifier: 2.5, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  minHandler,
+			status: http.StatusBadRequest,
+		},
+		"percentile handler, unknown method": {
+			url:    "/percentile",
+			req:    &Data{Qualifier: 50, Method: "cubic", Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  percentileHandler,
+			status: http.StatusBadRequest,
+		},
+		"quantiles handler, no percentiles": {
+			url:    "/quantiles",
+			req:    &Data{Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  quantilesHandler,
+			status: http.StatusBadRequest,
+		},
+		"quantiles handler, percentile out of range": {
+			url:    "/quantiles",
+			req:    &Data{Percentiles: []float64{50,101}, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  quantilesHandler,
+			status: http.StatusBadRequest,
+		},
+		"mode handler, empty dataset": {
+			url:    "/mode",
+			req:    &Data{},
+			hndlr:  modeHandler,
+			status: http.StatusBadRequest,
+		},
+		"histogram handler, empty dataset": {
+			url:    "/histogram",
+			req:    &Data{Edges: []float64{0,5,10}},
+			hndlr:  histogramHandler,
+			status: http.StatusBadRequest,
+		},
+		"histogram handler, unknown rule": {
+			url:    "/histogram",
+			req:    &Data{Bins: "rice", Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  histogramHandler,
+			status: http.StatusBadRequest,
+		},
+		"histogram handler, decreasing edges": {
+			url:    "/histogram",
+			req:    &Data{Edges: []float64{10,5,0}, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  histogramHandler,
+			status: http.StatusBadRequest,
+		},
+		"variance handler, empty dataset": {
+			url:    "/variance",
+			req:    &Data{},
+			hndlr:  varianceHandler,
+			status: http.StatusBadRequest,
+		},
+		"stddev handler, single sample": {
+			url:    "/stddev",
+			req:    &Data{Sample: true, Nums: []float64{9}},
+			hndlr:  stddevHandler,
+			status: http.StatusBadRequest,
+		},
+		"percentile handler, qualifier out of range": {
+			url:    "/percentile",
+			req:    &Data{Qualifier: 180, Nums: []float64{9,8,7,6,5,4,3,2,1}},
+			hndlr:  percentileHandler,
+			status: http.StatusBadRequest,
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -70,3 +214,37 @@ func TestHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestQuantilesResponse(t *testing.T) {
+	require := require.New(t)
+
+	erj, err := json.Marshal(&Data{Percentiles: []float64{50,90,99.9}, Method: "linear", Nums: []float64{1,2,3,4,5,6,7,8,9,10}})
+	require.NoError(err, "should marshal the payload")
+	req, err := http.NewRequest(http.MethodPost, "/quantiles", bytes.NewBuffer(erj))
+	require.NoError(err, "should setup the new request")
+
+	rr := httptest.NewRecorder()
+	registerHandlers().ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code, "should get the quantiles")
+
+	resp := &Response{}
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), resp), "should unmarshal the response")
+	require.Len(resp.Quantiles, 3, "should get each percentile asked for")
+	require.InDelta(5.5, resp.Quantiles["50"], 1e-9, "should get p50")
+	require.InDelta(9.1, resp.Quantiles["90"], 1e-9, "should get p90")
+	require.InDelta(9.991, resp.Quantiles["99.9"], 1e-9, "should get p99.9")
+}
+
+func TestHistogramResponse(t *testing.T) {
+	require := require.New(t)
+
+	erj, err := json.Marshal(&Data{Edges: []float64{0,5,10}, Nums: []float64{9,8,7,6,5,4,3,2,1}})
+	require.NoError(err, "should marshal the payload")
+	req, err := http.NewRequest(http.MethodPost, "/histogram", bytes.NewBuffer(erj))
+	require.NoError(err, "should setup the new request")
+
+	rr := httptest.NewRecorder()
+	registerHandlers().ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code, "should get the histogram")
+	require.JSONEq(`{"histogram":[{"lower":0,"upper":5,"count":4},{"lower":5,"upper":10,"count":5}]}`, rr.Body.String(), "should get the bins")
+}
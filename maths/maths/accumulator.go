@@ -0,0 +1,180 @@
+package maths
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Accumulator - running statistics over numbers seen one at a time, so a
+//  dataset never has to be held in memory. Tracks the count, sum, min, max,
+//  mean and variance, using Welford's algorithm for the mean and variance
+//  and a compensated sum, see Sum. The zero value is an empty accumulator
+//  ready to use.
+//  An Accumulator is not safe for concurrent use, give each goroutine or
+//  shard its own and combine them with Merge
+type Accumulator[TN Number] struct {
+	count int
+	sum   neumaier
+	min   TN
+	max   TN
+	mean  float64
+	m2    float64 // sum of squared deviations from the mean
+}
+
+// Add - add a number to the accumulator
+func (a *Accumulator[TN]) Add(val TN) {
+	if a.count == 0 || val < a.min {
+		a.min = val
+	}
+	if a.count == 0 || val > a.max {
+		a.max = val
+	}
+
+	a.count++
+	a.sum.add(float64(val))
+	d := float64(val) - a.mean
+	a.mean += d / float64(a.count)
+	a.m2 += d * (float64(val) - a.mean)
+}
+
+// AddMany - add each of the provided numbers to the accumulator
+func (a *Accumulator[TN]) AddMany(nums []TN) {
+	for _, val := range nums {
+		a.Add(val)
+	}
+}
+
+// Merge - add everything accumulated by b, as if its numbers had been added
+//  to a directly (Chan et al's parallel algorithm). b is left unchanged
+func (a *Accumulator[TN]) Merge(b *Accumulator[TN]) {
+	if b == nil || b.count == 0 {
+		return
+	}
+	if a.count == 0 {
+		*a = *b
+		return
+	}
+
+	if b.min < a.min {
+		a.min = b.min
+	}
+	if b.max > a.max {
+		a.max = b.max
+	}
+
+	n := float64(a.count + b.count)
+	d := b.mean - a.mean
+	a.mean += d * float64(b.count) / n
+	a.m2 += b.m2 + d*d*float64(a.count)*float64(b.count)/n
+	a.sum.add(b.sum.sum)
+	a.sum.add(b.sum.comp)
+	a.count += b.count
+}
+
+// Count - the number of numbers accumulated
+func (a *Accumulator[TN]) Count() int {
+	return a.count
+}
+
+// Sum - the sum of the numbers accumulated, 0 if there are none. Integers
+//  are summed as floats, so are only exact while the sum is below 2^53
+func (a *Accumulator[TN]) Sum() float64 {
+	return a.sum.value()
+}
+
+// Min - the lowest number accumulated
+func (a *Accumulator[TN]) Min() (TN, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyDataset
+	}
+	return a.min, nil
+}
+
+// Max - the highest number accumulated
+func (a *Accumulator[TN]) Max() (TN, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyDataset
+	}
+	return a.max, nil
+}
+
+// Mean - the mean of the numbers accumulated. Taken from the compensated
+//  sum, which holds on to precision better than Welford's running mean
+func (a *Accumulator[TN]) Mean() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyDataset
+	}
+	return a.sum.value() / float64(a.count), nil
+}
+
+// Variance - the population or sample variance of the numbers accumulated
+func (a *Accumulator[TN]) Variance(est Estimator) (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyDataset
+	}
+	if est == Sample {
+		if a.count < 2 {
+			return 0, fmt.Errorf("%w: sample variance needs at least 2 numbers, got %d", ErrInsufficientData, a.count)
+		}
+		return a.m2 / float64(a.count-1), nil
+	}
+	return a.m2 / float64(a.count), nil
+}
+
+// StdDev - the population or sample standard deviation of the numbers
+//  accumulated
+func (a *Accumulator[TN]) StdDev(est Estimator) (float64, error) {
+	v, err := a.Variance(est)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(v), nil
+}
+
+// accumulatorState - the serialized form of an Accumulator, enough to carry
+//  on accumulating exactly where it left off
+type accumulatorState[TN Number] struct {
+	Count   int     `json:"count"`
+	Sum     float64 `json:"sum"`
+	SumComp float64 `json:"sumComp"`
+	Min     TN      `json:"min"`
+	Max     TN      `json:"max"`
+	Mean    float64 `json:"mean"`
+	M2      float64 `json:"m2"`
+}
+
+// MarshalJSON - checkpoint the accumulator as json. Fails if a NaN or
+//  infinity has been accumulated, as json cannot represent them
+func (a Accumulator[TN]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&accumulatorState[TN]{
+		Count:   a.count,
+		Sum:     a.sum.sum,
+		SumComp: a.sum.comp,
+		Min:     a.min,
+		Max:     a.max,
+		Mean:    a.mean,
+		M2:      a.m2,
+	})
+}
+
+// UnmarshalJSON - restore an accumulator from a checkpoint made by MarshalJSON
+func (a *Accumulator[TN]) UnmarshalJSON(data []byte) error {
+	state := &accumulatorState[TN]{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return err
+	}
+	if state.Count < 0 || state.M2 < 0 || state.Min > state.Max {
+		return fmt.Errorf("%w: accumulator is inconsistent", ErrInvalidState)
+	}
+
+	*a = Accumulator[TN]{
+		count: state.Count,
+		sum:   neumaier{sum: state.Sum, comp: state.SumComp},
+		min:   state.Min,
+		max:   state.Max,
+		mean:  state.Mean,
+		m2:    state.M2,
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package maths
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireMatches - check the accumulator agrees with the batch functions
+func requireMatches(require *require.Assertions, nums []float64, acc *Accumulator[float64]) {
+	require.Equal(len(nums), acc.Count(), "should count every number")
+	require.InEpsilon(Sum(nums), acc.Sum(), 1e-12, "should match Sum")
+
+	min, err := acc.Min()
+	require.NoError(err, "should get the min")
+	require.Equal(Min(nums, 1)[0], min, "should match Min")
+	max, err := acc.Max()
+	require.NoError(err, "should get the max")
+	require.Equal(Max(nums, 1)[0], max, "should match Max")
+
+	mean, err := acc.Mean()
+	require.NoError(err, "should get the mean")
+	require.InEpsilon(Avg(nums), mean, 1e-12, "should match Avg")
+
+	for _, est := range []Estimator{Population, Sample} {
+		want, _ := Variance(nums, est)
+		vari, err := acc.Variance(est)
+		require.NoError(err, "should get the %s variance", est)
+		require.InEpsilon(want, vari, 1e-9, "should match the %s Variance", est)
+		want, _ = StdDev(nums, est)
+		sd, err := acc.StdDev(est)
+		require.NoError(err, "should get the %s standard deviation", est)
+		require.InEpsilon(want, sd, 1e-9, "should match the %s StdDev", est)
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	require := require.New(t)
+
+	rnd := rand.New(rand.NewSource(8))
+	nums := make([]float64, 10000)
+	for i := range nums {
+		nums[i] = 1e6 + rnd.NormFloat64()*50
+	}
+
+	acc := &Accumulator[float64]{}
+	for _, val := range nums[:10] {
+		acc.Add(val)
+	}
+	acc.AddMany(nums[10:])
+	requireMatches(require, nums, acc)
+
+	// split across shards of different sizes, and merge back together
+	shards := []*Accumulator[float64]{{}, {}, {}, {}}
+	for i, val := range nums {
+		shards[(i*i)%len(shards)].Add(val)
+	}
+	merged := &Accumulator[float64]{}
+	merged.Merge(&Accumulator[float64]{})
+	for _, shard := range shards {
+		merged.Merge(shard)
+	}
+	merged.Merge(nil)
+	requireMatches(require, nums, merged)
+}
+
+func TestAccumulatorInts(t *testing.T) {
+	require := require.New(t)
+
+	acc := &Accumulator[int8]{}
+	acc.AddMany([]int8{120,124,126,122})
+
+	min, err := acc.Min()
+	require.NoError(err, "should get the min")
+	require.Equal(int8(120), min, "should get the min")
+	require.Equal(float64(492), acc.Sum(), "should sum without overflowing int8")
+	mean, err := acc.Mean()
+	require.NoError(err, "should get the mean")
+	require.Equal(float64(123), mean, "should get the mean")
+	vari, err := acc.Variance(Population)
+	require.NoError(err, "should get the variance")
+	require.InDelta(float64(5), vari, 1e-12, "should get the variance")
+}
+
+func TestAccumulatorEmpty(t *testing.T) {
+	require := require.New(t)
+
+	acc := &Accumulator[int]{}
+	require.Equal(0, acc.Count(), "should be empty")
+	require.Equal(float64(0), acc.Sum(), "empty sum should be 0")
+	_, err := acc.Min()
+	require.ErrorIs(err, ErrEmptyDataset, "should have no min")
+	_, err = acc.Max()
+	require.ErrorIs(err, ErrEmptyDataset, "should have no max")
+	_, err = acc.Mean()
+	require.ErrorIs(err, ErrEmptyDataset, "should have no mean")
+	_, err = acc.Variance(Population)
+	require.ErrorIs(err, ErrEmptyDataset, "should have no variance")
+
+	acc.Add(3)
+	_, err = acc.StdDev(Sample)
+	require.ErrorIs(err, ErrInsufficientData, "should need two numbers for a sample")
+}
+
+func TestAccumulatorJSON(t *testing.T) {
+	require := require.New(t)
+
+	nums := []float64{0.1, 0.2, 0.3, 1e6, -3.5, 7.25}
+	acc := &Accumulator[float64]{}
+	acc.AddMany(nums[:3])
+
+	// checkpoint part way, restore and carry on
+	data, err := json.Marshal(acc)
+	require.NoError(err, "should marshal the accumulator")
+	restored := &Accumulator[float64]{}
+	require.NoError(json.Unmarshal(data, restored), "should unmarshal the accumulator")
+	require.Equal(acc, restored, "should restore exactly")
+
+	acc.AddMany(nums[3:])
+	restored.AddMany(nums[3:])
+	require.Equal(acc, restored, "should carry on exactly as the original")
+	requireMatches(require, nums, restored)
+
+	err = json.Unmarshal([]byte(`{"count":-1}`), restored)
+	require.ErrorIs(err, ErrInvalidState, "should reject a negative count")
+	err = json.Unmarshal([]byte(`{"count":2,"min":3,"max":1}`), restored)
+	require.ErrorIs(err, ErrInvalidState, "should reject min over max")
+}
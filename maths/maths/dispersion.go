@@ -0,0 +1,83 @@
+package maths
+
+import (
+	"fmt"
+	"math"
+)
+
+// Estimator - whether a measure of spread describes the whole population,
+//  or estimates the population's from a sample of it
+type Estimator int
+
+const (
+	// Population - divide by n, the data is everything there is
+	Population Estimator = iota
+	// Sample - divide by n-1 (Bessel's correction), the data is a sample
+	Sample
+)
+
+// String - print the estimator name
+func (e Estimator) String() string {
+	if e == Sample {
+		return "sample"
+	}
+	return "population"
+}
+
+// Variance - the population or sample variance of the provided numbers
+//  Uses the corrected two-pass algorithm: the mean is found first, then the
+//  squared deviations from it are summed, less a correction for the rounding
+//  error in the mean. This avoids the catastrophic cancellation of the
+//  textbook sum-of-squares formula when the values are large and close
+//  together. A sample needs at least two numbers
+func Variance[TN Number](nums []TN, est Estimator) (float64, error) {
+	n := len(nums)
+	if n == 0 {
+		return 0, ErrEmptyDataset
+	}
+	denom := float64(n)
+	if est == Sample {
+		if n < 2 {
+			return 0, fmt.Errorf("%w: sample variance needs at least 2 numbers, got %d", ErrInsufficientData, n)
+		}
+		denom = float64(n - 1)
+	}
+
+	mean := Avg(nums)
+	var squares, devs float64
+	for _, val := range nums {
+		d := float64(val) - mean
+		squares += d * d
+		devs += d
+	}
+	// with an exact mean devs would be 0, what is left is the rounding error
+	squares -= devs * devs / float64(n)
+	if squares < 0 {
+		squares = 0
+	}
+	return squares / denom, nil
+}
+
+// StdDev - the population or sample standard deviation of the provided
+//  numbers, the square root of their Variance
+func StdDev[TN Number](nums []TN, est Estimator) (float64, error) {
+	v, err := Variance(nums, est)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(v), nil
+}
+
+// CoefficientOfVariation - the standard deviation of the provided numbers
+//  relative to their mean. Undefined, and an error, when the mean is 0
+func CoefficientOfVariation[TN Number](nums []TN, est Estimator) (float64, error) {
+	sd, err := StdDev(nums, est)
+	if err != nil {
+		return 0, err
+	}
+	mean := Avg(nums)
+	if mean == 0 {
+		return 0, ErrZeroMean
+	}
+	return sd / mean, nil
+}
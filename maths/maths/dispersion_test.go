@@ -0,0 +1,120 @@
+package maths
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariance(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct{
+		input interface{}
+		est   Estimator
+		vari  float64
+		sd    float64
+		cv    float64
+		err   error
+	}{
+		"population": {
+			input: []float64{2,4,4,4,5,5,7,9},
+			est:   Population,
+			vari:  4,
+			sd:    2,
+			cv:    0.4,
+		},
+		"sample": {
+			input: []float64{2,4,4,4,5,5,7,9},
+			est:   Sample,
+			vari:  float64(32)/7,
+			sd:    math.Sqrt(float64(32)/7),
+			cv:    math.Sqrt(float64(32)/7)/5,
+		},
+		"ints": {
+			input: []int{2,4,4,4,5,5,7,9},
+			est:   Population,
+			vari:  4,
+			sd:    2,
+			cv:    0.4,
+		},
+		"large values close together": {
+			input: []float64{1e9+4,1e9+7,1e9+13,1e9+16},
+			est:   Sample,
+			vari:  30,
+			sd:    math.Sqrt(30),
+			cv:    math.Sqrt(30)/(1e9+10),
+		},
+		"int8 that would overflow": {
+			input: []int8{120,124,126,122},
+			est:   Population,
+			vari:  5,
+			sd:    math.Sqrt(5),
+			cv:    math.Sqrt(5)/123,
+		},
+		"constant": {
+			input: []float64{3,3,3},
+			est:   Sample,
+			vari:  0,
+			sd:    0,
+			cv:    0,
+		},
+		"single population": {
+			input: []float64{3},
+			est:   Population,
+			vari:  0,
+			sd:    0,
+			cv:    0,
+		},
+		"single sample": {
+			input: []float64{3},
+			est:   Sample,
+			err:   ErrInsufficientData,
+		},
+		"empty list": {
+			input: []float64{},
+			est:   Population,
+			err:   ErrEmptyDataset,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var vari, sd, cv float64
+			var verr, sderr, cverr error
+			switch inp := tc.input.(type) {
+			case []int:
+				vari, verr = Variance(inp, tc.est)
+				sd, sderr = StdDev(inp, tc.est)
+				cv, cverr = CoefficientOfVariation(inp, tc.est)
+			case []int8:
+				vari, verr = Variance(inp, tc.est)
+				sd, sderr = StdDev(inp, tc.est)
+				cv, cverr = CoefficientOfVariation(inp, tc.est)
+			case []float64:
+				vari, verr = Variance(inp, tc.est)
+				sd, sderr = StdDev(inp, tc.est)
+				cv, cverr = CoefficientOfVariation(inp, tc.est)
+			default:
+				require.FailNow("Unhandled input type provided")
+			}
+
+			if tc.err != nil {
+				require.ErrorIs(verr, tc.err, "Variance should return the expected error")
+				require.ErrorIs(sderr, tc.err, "StdDev should return the expected error")
+				require.ErrorIs(cverr, tc.err, "CoefficientOfVariation should return the expected error")
+				return
+			}
+			require.NoError(verr, "Variance should not error")
+			require.InDelta(tc.vari, vari, 1e-9, "should get the expected %s variance", tc.est)
+			require.NoError(sderr, "StdDev should not error")
+			require.InDelta(tc.sd, sd, 1e-9, "should get the expected %s standard deviation", tc.est)
+			require.NoError(cverr, "CoefficientOfVariation should not error")
+			require.InDelta(tc.cv, cv, 1e-12, "should get the expected %s coefficient of variation", tc.est)
+		})
+	}
+
+	_, err := CoefficientOfVariation([]float64{-1,1}, Population)
+	require.ErrorIs(err, ErrZeroMean, "should reject a zero mean")
+}
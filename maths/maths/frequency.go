@@ -0,0 +1,237 @@
+package maths
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Frequencies - how many times each value appears in the provided numbers
+//  NaN is never equal to itself, so cannot be counted, and is skipped
+func Frequencies[TN Number](nums []TN) map[TN]int {
+	freq := map[TN]int{}
+	for _, val := range nums {
+		if val != val {
+			continue
+		}
+		freq[val]++
+	}
+	return freq
+}
+
+// Mode - the most common value of the provided numbers. If several values
+//  tie for most common they are all returned, in ascending order
+func Mode[TN Number](nums []TN) ([]TN, error) {
+	freq := Frequencies(nums)
+	if len(freq) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	modes := []TN{}
+	most := 0
+	for val, count := range freq {
+		if count > most {
+			most = count
+			modes = modes[:0]
+		}
+		if count == most {
+			modes = append(modes, val)
+		}
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+	return modes, nil
+}
+
+// maxBins - the most bins FixedWidthEdges and Edges will make, as a stray
+//  outlier can otherwise ask for millions
+const maxBins = 10000
+
+// Bin - one bin of a histogram, counting the numbers from Lower up to but
+//  not including Upper. The last bin of a histogram also includes its Upper
+type Bin struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// Histogram - count the provided numbers into the bins between each pair
+//  of consecutive edges. Numbers outside the edges, and NaN, are not counted
+func Histogram[TN Number](nums []TN, edges []float64) ([]Bin, error) {
+	if len(edges) < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 edges, got %d", ErrInvalidBins, len(edges))
+	}
+	for i, edge := range edges {
+		if math.IsNaN(edge) || math.IsInf(edge, 0) {
+			return nil, fmt.Errorf("%w: edge %d is %v", ErrInvalidBins, i, edge)
+		}
+		if i > 0 && edge <= edges[i-1] {
+			return nil, fmt.Errorf("%w: edges must be increasing, edge %d is %v after %v", ErrInvalidBins, i, edge, edges[i-1])
+		}
+	}
+
+	bins := make([]Bin, len(edges)-1)
+	for i := range bins {
+		bins[i].Lower, bins[i].Upper = edges[i], edges[i+1]
+	}
+
+	last := edges[len(edges)-1]
+	for _, val := range nums {
+		v := float64(val)
+		if !(v >= edges[0] && v <= last) {
+			continue
+		}
+		// the first edge above v closes its bin, the top edge closes the last
+		i := sort.Search(len(edges), func(i int) bool { return edges[i] > v })
+		if i == len(edges) {
+			i--
+		}
+		bins[i-1].Count++
+	}
+	return bins, nil
+}
+
+// BinRule - a rule for choosing how many bins a histogram should have from
+//  the data, see Edges
+type BinRule int
+
+const (
+	// Sturges - log2(n)+1 bins, suits small, roughly normal datasets
+	Sturges BinRule = iota
+	// Scott - bins 3.5σ/∛n wide, optimal for normal data
+	Scott
+	// FreedmanDiaconis - bins 2·IQR/∛n wide, robust to outliers
+	FreedmanDiaconis
+)
+
+// binRuleNames - the name of each rule
+var binRuleNames = []string{
+	"sturges",
+	"scott",
+	"fd",
+}
+
+// String - print the rule name
+func (r BinRule) String() string {
+	if r < 0 || int(r) >= len(binRuleNames) {
+		return fmt.Sprintf("BinRule(%d)", int(r))
+	}
+	return binRuleNames[r]
+}
+
+// ParseBinRule - get the rule for a name, as returned by String, or
+//  "freedman-diaconis" for FreedmanDiaconis. An empty name is Sturges
+func ParseBinRule(s string) (BinRule, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "":
+		return Sturges, nil
+	case "freedman-diaconis":
+		return FreedmanDiaconis, nil
+	}
+	for i, name := range binRuleNames {
+		if s == name {
+			return BinRule(i), nil
+		}
+	}
+	return Sturges, fmt.Errorf("%w: %q", ErrUnknownMethod, s)
+}
+
+// span - the lowest and highest of the provided numbers, which must all be finite
+func span[TN Number](nums []TN) (float64, float64, error) {
+	if len(nums) == 0 {
+		return 0, 0, ErrEmptyDataset
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, val := range nums {
+		v := float64(val)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, 0, fmt.Errorf("%w: element %d is %v", ErrNonFinite, i, v)
+		}
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	return lo, hi, nil
+}
+
+// FixedWidthEdges - edges for bins of the given width, starting from the
+//  lowest of the provided numbers, and enough of them to reach the highest
+func FixedWidthEdges[TN Number](nums []TN, width float64) ([]float64, error) {
+	if !(width > 0) || math.IsInf(width, 0) {
+		return nil, fmt.Errorf("%w: width %v must be above 0", ErrInvalidBins, width)
+	}
+	lo, hi, err := span(nums)
+	if err != nil {
+		return nil, err
+	}
+
+	bins := math.Ceil((hi - lo) / width)
+	if bins > maxBins {
+		return nil, fmt.Errorf("%w: width %v needs %v bins, the most is %d", ErrInvalidBins, width, bins, maxBins)
+	}
+	count := int(math.Max(bins, 1))
+
+	edges := make([]float64, count+1)
+	for i := range edges {
+		edges[i] = lo + float64(i)*width
+	}
+	return edges, nil
+}
+
+// Edges - evenly spaced edges spanning the provided numbers, with the
+//  number of bins chosen by the rule, as numpy's histogram_bin_edges, up to
+//  a limit of 10000 bins. If every number is the same, a single bin of width
+//  1 centred on it
+func Edges[TN Number](nums []TN, rule BinRule) ([]float64, error) {
+	lo, hi, err := span(nums)
+	if err != nil {
+		return nil, err
+	}
+	n := float64(len(nums))
+
+	// width - bins of width h, or just the one if the data has no spread
+	width := func(h float64) int {
+		if h <= 0 {
+			return 1
+		}
+		return int(math.Min(math.Ceil((hi-lo)/h), maxBins))
+	}
+
+	var count int
+	switch rule {
+	case Sturges:
+		count = int(math.Ceil(math.Log2(n))) + 1
+	case Scott:
+		sd, err := StdDev(nums, Population)
+		if err != nil {
+			return nil, err
+		}
+		count = width(math.Cbrt(24*math.Sqrt(math.Pi)/n) * sd)
+	case FreedmanDiaconis:
+		q, err := QuantilesCopy(nums, []float64{0.25, 0.75}, Linear)
+		if err != nil {
+			return nil, err
+		}
+		count = width(2 * (q[1] - q[0]) / math.Cbrt(n))
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnknownMethod, rule)
+	}
+
+	if lo == hi {
+		lo, hi, count = lo-0.5, hi+0.5, 1
+	}
+	if count > maxBins {
+		count = maxBins
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	edges := make([]float64, count+1)
+	for i := range edges {
+		edges[i] = lo + (hi-lo)*float64(i)/float64(count)
+	}
+	// land exactly on the top, rather than a rounding error short of it
+	edges[count] = hi
+	return edges, nil
+}
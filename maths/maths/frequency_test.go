@@ -0,0 +1,165 @@
+package maths
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMode(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct{
+		input []float64
+		mode  []float64
+		err   error
+	}{
+		"single mode": {
+			input: []float64{1,2,2,3,3,3,4,4,4,4},
+			mode:  []float64{4},
+		},
+		"multimodal": {
+			input: []float64{3,1,2,1,2},
+			mode:  []float64{1,2},
+		},
+		"all unique": {
+			input: []float64{3,1,2},
+			mode:  []float64{1,2,3},
+		},
+		"NaN skipped": {
+			input: []float64{math.NaN(),math.NaN(),5},
+			mode:  []float64{5},
+		},
+		"empty list": {
+			input: []float64{},
+			err:   ErrEmptyDataset,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mode, err := Mode(tc.input)
+			if tc.err != nil {
+				require.ErrorIs(err, tc.err, "should return the expected error")
+				return
+			}
+			require.NoError(err, "should get the mode")
+			require.Equal(tc.mode, mode, "should get the expected mode")
+		})
+	}
+
+	ints, err := Mode([]int{7,7,-1,-1,0})
+	require.NoError(err, "should get the mode of ints")
+	require.Equal([]int{-1,7}, ints, "should get the modes of ints in order")
+}
+
+func TestFrequencies(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(map[int]int{1: 1, 2: 2, 3: 3}, Frequencies([]int{3,2,3,1,2,3}), "should count each value")
+	require.Equal(map[float64]int{}, Frequencies([]float64{}), "should count nothing")
+	require.Equal(map[float64]int{0.5: 1}, Frequencies([]float64{math.NaN(), 0.5}), "should skip NaN")
+}
+
+func TestHistogram(t *testing.T) {
+	require := require.New(t)
+
+	nums := []float64{1,2,2,3,3,3,4,4,4,4}
+	bins, err := Histogram(nums, []float64{0,1.5,3,10})
+	require.NoError(err, "should bin with explicit edges")
+	require.Equal([]Bin{{0,1.5,1},{1.5,3,2},{3,10,7}}, bins, "should count into the bins")
+
+	bins, err = Histogram(append(nums, -5, 11, math.NaN()), []float64{1,2,4})
+	require.NoError(err, "should bin numbers outside the edges")
+	require.Equal([]Bin{{1,2,1},{2,4,9}}, bins, "should not count numbers outside, and include the top edge")
+
+	for tn, edges := range map[string][]float64{
+		"too few":    {1},
+		"decreasing": {1,3,2},
+		"repeated":   {1,2,2},
+		"NaN":        {1,math.NaN()},
+		"infinite":   {1,math.Inf(1)},
+	} {
+		_, err := Histogram(nums, edges)
+		require.ErrorIs(err, ErrInvalidBins, "should reject %s edges", tn)
+	}
+}
+
+func TestEdges(t *testing.T) {
+	require := require.New(t)
+
+	// numpy.histogram_bin_edges(nums, bins=rule)
+	nums := []int{1,2,2,3,3,3,4,4,4,4}
+	testCases := map[BinRule][]float64{
+		Sturges:          {1,1.6,2.2,2.8,3.4,4},
+		Scott:            {1,2.5,4},
+		FreedmanDiaconis: {1,2.5,4},
+	}
+	for rule, want := range testCases {
+		edges, err := Edges(nums, rule)
+		require.NoError(err, "should get the %s edges", rule)
+		require.InDeltaSlice(want, edges, 1e-12, "should get the %s edges", rule)
+	}
+
+	bins, err := Histogram(nums, []float64{1,1.6,2.2,2.8,3.4,4})
+	require.NoError(err, "should bin with the sturges edges")
+	require.Equal([]int{1,2,0,3,4}, []int{bins[0].Count,bins[1].Count,bins[2].Count,bins[3].Count,bins[4].Count}, "should count into the sturges bins")
+
+	edges, err := Edges([]float64{5,5,5}, FreedmanDiaconis)
+	require.NoError(err, "should get edges with no spread")
+	require.Equal([]float64{4.5,5.5}, edges, "should get a single bin centred on the value")
+
+	// a far outlier asks for more bins than allowed
+	edges, err = Edges([]float64{0,0,0,0,1,1e12}, FreedmanDiaconis)
+	require.NoError(err, "should get edges with an outlier")
+	require.Len(edges, maxBins+1, "should limit the number of bins")
+
+	_, err = Edges([]float64{}, Sturges)
+	require.ErrorIs(err, ErrEmptyDataset, "should reject an empty dataset")
+	_, err = Edges([]float64{1,math.Inf(1)}, Sturges)
+	require.ErrorIs(err, ErrNonFinite, "should reject infinity")
+	_, err = Edges(nums, BinRule(7))
+	require.ErrorIs(err, ErrUnknownMethod, "should reject an unknown rule")
+}
+
+func TestFixedWidthEdges(t *testing.T) {
+	require := require.New(t)
+
+	edges, err := FixedWidthEdges([]float64{1,2,2,3,3,3,4,4,4,4}, 1)
+	require.NoError(err, "should get the edges")
+	require.Equal([]float64{1,2,3,4}, edges, "should step by the width from the lowest")
+
+	edges, err = FixedWidthEdges([]int{1,5}, 3)
+	require.NoError(err, "should get the edges")
+	require.Equal([]float64{1,4,7}, edges, "should reach past the highest")
+
+	edges, err = FixedWidthEdges([]int{2,2}, 3)
+	require.NoError(err, "should get the edges with no spread")
+	require.Equal([]float64{2,5}, edges, "should get a single bin")
+
+	_, err = FixedWidthEdges([]int{1,2}, 0)
+	require.ErrorIs(err, ErrInvalidBins, "should reject a zero width")
+	_, err = FixedWidthEdges([]float64{0,1e12}, 1)
+	require.ErrorIs(err, ErrInvalidBins, "should reject too many bins")
+	_, err = FixedWidthEdges([]int{}, 1)
+	require.ErrorIs(err, ErrEmptyDataset, "should reject an empty dataset")
+}
+
+func TestParseBinRule(t *testing.T) {
+	require := require.New(t)
+
+	for _, rule := range []BinRule{Sturges, Scott, FreedmanDiaconis} {
+		r, err := ParseBinRule(rule.String())
+		require.NoError(err, "should parse the name %q", rule.String())
+		require.Equal(rule, r, "should round trip the name")
+	}
+	r, err := ParseBinRule("Freedman-Diaconis")
+	require.NoError(err, "should parse the long name")
+	require.Equal(FreedmanDiaconis, r, "should parse the long name")
+	r, err = ParseBinRule("")
+	require.NoError(err, "should parse an empty name")
+	require.Equal(Sturges, r, "no name is sturges")
+	_, err = ParseBinRule("rice")
+	require.ErrorIs(err, ErrUnknownMethod, "should reject an unknown rule")
+}
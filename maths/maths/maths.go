@@ -1,7 +1,7 @@
 package maths
 
 import (
-	"sort"
+	"fmt"
 )
 
 // Number - type constraint for numeric types
@@ -9,7 +9,19 @@ type Number interface {
 	int | int8 | int16 |int32 | int64 | float32 | float64
 }
 
-// Min - return the lowest 'n' numbers from the provided numbers
+var (
+	ErrEmptyDataset = fmt.Errorf("Empty dataset, at least one number is required")
+	ErrQualifierOutOfRange = fmt.Errorf("Qualifier out of range")
+	ErrNonFinite = fmt.Errorf("Dataset contains a NaN or infinite value")
+	ErrInsufficientData = fmt.Errorf("Dataset has too few numbers")
+	ErrZeroMean = fmt.Errorf("Dataset has a mean of 0")
+	ErrUnknownMethod = fmt.Errorf("Unknown method")
+	ErrInvalidState = fmt.Errorf("Invalid serialized state")
+	ErrInvalidBins = fmt.Errorf("Invalid histogram bins")
+)
+
+// Min - return the lowest 'n' numbers from the provided numbers, in
+//  ascending order. nums is left unchanged, see Smallest
 func Min[TN Number](nums []TN, qualifier int) []TN {
 	// should always return at least one number
 	if qualifier <= 0 {
@@ -21,12 +33,11 @@ func Min[TN Number](nums []TN, qualifier int) []TN {
 		return nums
 	}
 
-	// sort the numbers in ascending, and return the frist q elements
-	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
-	return nums[:qualifier]
+	return Smallest(nums, qualifier)
 }
 
-// Max - return the highest 'n' numbers from the provided numbers 
+// Max - return the highest 'n' numbers from the provided numbers, in
+//  descending order. nums is left unchanged, see Largest
 func Max[TN Number](nums []TN, qualifier int) []TN {
 	// should always return at least one number
 	if qualifier <= 0 {
@@ -38,12 +49,11 @@ func Max[TN Number](nums []TN, qualifier int) []TN {
 		return nums
 	}
 
-	// sort the numbers in descending, and return the frist q elements
-	sort.Slice(nums, func(i, j int) bool { return nums[i] > nums[j] })
-	return nums[:qualifier]
+	return Largest(nums, qualifier)
 }
 
-// Avg - the mean of the the provided numbers
+// Avg - the mean of the the provided numbers, summed without overflow and
+//  with compensation for rounding, see Sum. For an exact answer use AvgExact
 func Avg[TN Number](nums []TN) float64 {
 	if len(nums) < 1 {
 		return 0
@@ -51,40 +61,143 @@ func Avg[TN Number](nums []TN) float64 {
 		return float64(nums[0])
 	}
 
-	var sum TN = 0
-	for _, val := range nums {
-		sum += val
-	}
-	return float64(sum)/float64(len(nums))
+	return Sum(nums)/float64(len(nums))
 }
 
 // Median - return the median of the provided numbers
+//  nums is partially reordered in place, use MedianCopy to leave it unchanged
 func Median[TN Number](nums []TN) float64 {
 	if len(nums) == 0 {
 		return 0
 	}
 
-	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
-
-	// get the middle index of the array
+	// get the middle index of the array, and move the middle value into it
 	m := len(nums)/2
+	Select(nums, m)
 	// if the array length is odd, this will be the value to return
 	if len(nums) % 2 != 0 {
 		return float64(nums[m])
 	}
-	// otherwise, get the average of the two middle values
+
+	// otherwise the other middle value is the largest of the lower half,
+	// move it alongside and get the average of the two middle values
+	low := 0
+	for i := 1; i < m; i++ {
+		if nums[i] > nums[low] {
+			low = i
+		}
+	}
+	nums[low], nums[m-1] = nums[m-1], nums[low]
 	return Avg(nums[m-1:m+1])
 }
 
 // Percentile - return the qth percentile for the provided set of numbers
 //  using the nearest-rank method, the returned value will always be one of 
 //  the provided numbers
+//  nums is partially reordered in place, use PercentileCopy to leave it unchanged
 func Percentile[TN Number](nums []TN, q int) TN {
-	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
 	// get the index of the last value less than or equal to the percenile
 	p := ((q * len(nums)) / 100) - 1
 	if p < 0 {
 		p = 0
 	}
-	return nums[p]
+	return Select(nums, p)
+}
+
+// clone - return a copy of the provided numbers, so they can be reordered
+//  without changing the caller's slice
+func clone[TN Number](nums []TN) []TN {
+	c := make([]TN, len(nums))
+	copy(c, nums)
+	return c
+}
+
+// MinCopy - as Min, but the result never shares memory with nums. Min
+//  already leaves nums unchanged, so a copy of nums (len(nums) elements) is
+//  only made when every number is returned, otherwise this allocates the
+//  qualifier elements of the result
+func MinCopy[TN Number](nums []TN, qualifier int) []TN {
+	if qualifier > len(nums) {
+		return clone(nums)
+	}
+	return Min(nums, qualifier)
+}
+
+// MaxCopy - as Max, but the result never shares memory with nums. Max
+//  already leaves nums unchanged, so a copy of nums (len(nums) elements) is
+//  only made when every number is returned, otherwise this allocates the
+//  qualifier elements of the result
+func MaxCopy[TN Number](nums []TN, qualifier int) []TN {
+	if qualifier > len(nums) {
+		return clone(nums)
+	}
+	return Max(nums, qualifier)
+}
+
+// MedianCopy - as Median, but leaves nums unchanged. A copy of nums is
+//  made before selecting, so this allocates len(nums) elements on every call
+func MedianCopy[TN Number](nums []TN) float64 {
+	return Median(clone(nums))
+}
+
+// PercentileCopy - as Percentile, but leaves nums unchanged. A copy of nums
+//  is made before selecting, so this allocates len(nums) elements on every call
+func PercentileCopy[TN Number](nums []TN, q int) TN {
+	return Percentile(clone(nums), q)
+}
+
+// MinErr - as Min, but an empty dataset or a negative qualifier is reported
+//  as an error rather than quietly giving an answer. A qualifier of 0 still
+//  returns a single number
+func MinErr[TN Number](nums []TN, qualifier int) ([]TN, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmptyDataset
+	}
+	if qualifier < 0 {
+		return nil, fmt.Errorf("%w: count %d cannot be negative", ErrQualifierOutOfRange, qualifier)
+	}
+	return Min(nums, qualifier), nil
+}
+
+// MaxErr - as Max, but an empty dataset or a negative qualifier is reported
+//  as an error rather than quietly giving an answer. A qualifier of 0 still
+//  returns a single number
+func MaxErr[TN Number](nums []TN, qualifier int) ([]TN, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmptyDataset
+	}
+	if qualifier < 0 {
+		return nil, fmt.Errorf("%w: count %d cannot be negative", ErrQualifierOutOfRange, qualifier)
+	}
+	return Max(nums, qualifier), nil
+}
+
+// AvgErr - as Avg, but an empty dataset is an error rather than 0
+func AvgErr[TN Number](nums []TN) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyDataset
+	}
+	return Avg(nums), nil
+}
+
+// MedianErr - as Median, but an empty dataset is an error rather than 0
+//  nums is partially reordered in place, as with Median
+func MedianErr[TN Number](nums []TN) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyDataset
+	}
+	return Median(nums), nil
+}
+
+// PercentileErr - as Percentile, but an empty dataset or a percentile
+//  outside 0..100 is an error rather than a panic or a clamped answer
+//  nums is partially reordered in place, as with Percentile
+func PercentileErr[TN Number](nums []TN, q int) (TN, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyDataset
+	}
+	if q < 0 || q > 100 {
+		return 0, fmt.Errorf("%w: percentile %d is not between 0 and 100", ErrQualifierOutOfRange, q)
+	}
+	return Percentile(nums, q), nil
 }
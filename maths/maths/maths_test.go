@@ -319,3 +319,139 @@ func TestPercentile(t *testing.T) {
 		})
 	}
 }
+
+// rawBits - the raw representation of the numbers, so comparisons are byte-for-byte
+func rawBits(nums []float64) []uint64 {
+	b := make([]uint64, len(nums))
+	for i, n := range nums {
+		b[i] = math.Float64bits(n)
+	}
+	return b
+}
+
+func TestCopyVariants(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct{
+		input []float64
+		qual  int
+	}{
+		"unsorted": {
+			input: []float64{8,3,7,2,9,1,5,4,6,0},
+			qual:  3,
+		},
+		"with repeats and signed zeros": {
+			input: []float64{8,2,math.Copysign(0, -1),3,5,2,9,0,8,9,-4.5},
+			qual:  50,
+		},
+		"single": {
+			input: []float64{4},
+			qual:  1,
+		},
+		"empty list": {
+			input: []float64{},
+			qual:  2,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			orig := rawBits(tc.input)
+
+			require.Equal(Min(clone(tc.input), tc.qual), MinCopy(tc.input, tc.qual), "MinCopy should match Min")
+			require.Equal(orig, rawBits(tc.input), "MinCopy should not change the input")
+
+			require.Equal(Max(clone(tc.input), tc.qual), MaxCopy(tc.input, tc.qual), "MaxCopy should match Max")
+			require.Equal(orig, rawBits(tc.input), "MaxCopy should not change the input")
+
+			require.Equal(Median(clone(tc.input)), MedianCopy(tc.input), "MedianCopy should match Median")
+			require.Equal(orig, rawBits(tc.input), "MedianCopy should not change the input")
+
+			if len(tc.input) > 0 {
+				require.Equal(Percentile(clone(tc.input), tc.qual), PercentileCopy(tc.input, tc.qual), "PercentileCopy should match Percentile")
+				require.Equal(orig, rawBits(tc.input), "PercentileCopy should not change the input")
+			}
+		})
+	}
+}
+
+func TestErrVariants(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct{
+		input []float64
+		qual  int
+		err   error
+	}{
+		"valid": {
+			input: []float64{8,3,7,2,9,1},
+			qual:  50,
+		},
+		"qual 0": {
+			input: []float64{8,3,7,2,9,1},
+			qual:  0,
+		},
+		"qual 100": {
+			input: []float64{8,3,7,2,9,1},
+			qual:  100,
+		},
+		"empty list": {
+			input: []float64{},
+			qual:  50,
+			err:   ErrEmptyDataset,
+		},
+		"nil list": {
+			qual:  50,
+			err:   ErrEmptyDataset,
+		},
+		"negative qual": {
+			input: []float64{8,3,7,2,9,1},
+			qual:  -1,
+			err:   ErrQualifierOutOfRange,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mins, err := MinErr(clone(tc.input), tc.qual)
+			if tc.err != nil {
+				require.ErrorIs(err, tc.err, "MinErr should return the expected error")
+			} else {
+				require.NoError(err, "MinErr should not error")
+				require.Equal(Min(clone(tc.input), tc.qual), mins, "MinErr should match Min")
+			}
+
+			maxs, err := MaxErr(clone(tc.input), tc.qual)
+			if tc.err != nil {
+				require.ErrorIs(err, tc.err, "MaxErr should return the expected error")
+			} else {
+				require.NoError(err, "MaxErr should not error")
+				require.Equal(Max(clone(tc.input), tc.qual), maxs, "MaxErr should match Max")
+			}
+
+			pct, err := PercentileErr(clone(tc.input), tc.qual)
+			if tc.err != nil {
+				require.ErrorIs(err, tc.err, "PercentileErr should return the expected error")
+			} else {
+				require.NoError(err, "PercentileErr should not error")
+				require.Equal(Percentile(clone(tc.input), tc.qual), pct, "PercentileErr should match Percentile")
+			}
+
+			// avg and median have no qualifier, so only fail on the dataset
+			avg, err := AvgErr(tc.input)
+			med, merr := MedianErr(clone(tc.input))
+			if len(tc.input) == 0 {
+				require.ErrorIs(err, ErrEmptyDataset, "AvgErr should reject an empty dataset")
+				require.ErrorIs(merr, ErrEmptyDataset, "MedianErr should reject an empty dataset")
+			} else {
+				require.NoError(err, "AvgErr should not error")
+				require.Equal(Avg(tc.input), avg, "AvgErr should match Avg")
+				require.NoError(merr, "MedianErr should not error")
+				require.Equal(Median(clone(tc.input)), med, "MedianErr should match Median")
+			}
+		})
+	}
+
+	_, err := PercentileErr([]int{1,2,3}, 101)
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject a percentile over 100")
+}
@@ -0,0 +1,232 @@
+package maths
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// QuantileMethod - how a quantile is picked or interpolated from the order
+//  statistics of a dataset. Other than NearestRank these are the nine
+//  definitions of Hyndman & Fan (1996), numbered to match R's quantile
+//  types 1 to 9, and named as numpy names them
+type QuantileMethod int
+
+const (
+	// NearestRank - the definition Percentile has always used, the value at
+	//  rank floor(p*n), so always one of the numbers
+	NearestRank QuantileMethod = iota
+	// InvertedCDF - R type 1, the inverse of the empirical distribution function
+	InvertedCDF
+	// AveragedInvertedCDF - R type 2, as type 1 but averaging at discontinuities
+	AveragedInvertedCDF
+	// ClosestObservation - R type 3, the nearest even order statistic (SAS)
+	ClosestObservation
+	// InterpolatedInvertedCDF - R type 4, linear interpolation of the empirical CDF
+	InterpolatedInvertedCDF
+	// Hazen - R type 5, piecewise linear with knots half way between the values
+	Hazen
+	// Weibull - R type 6, used by Minitab and SPSS
+	Weibull
+	// Linear - R type 7, the default in R, numpy and Excel
+	Linear
+	// MedianUnbiased - R type 8, approximately median-unbiased whatever the distribution
+	MedianUnbiased
+	// NormalUnbiased - R type 9, approximately unbiased for normal distributions
+	NormalUnbiased
+)
+
+// quantileMethodNames - the name of each method, as numpy names them
+var quantileMethodNames = []string{
+	"nearest-rank",
+	"inverted-cdf",
+	"averaged-inverted-cdf",
+	"closest-observation",
+	"interpolated-inverted-cdf",
+	"hazen",
+	"weibull",
+	"linear",
+	"median-unbiased",
+	"normal-unbiased",
+}
+
+// String - print the method name
+func (m QuantileMethod) String() string {
+	if m < 0 || int(m) >= len(quantileMethodNames) {
+		return fmt.Sprintf("QuantileMethod(%d)", int(m))
+	}
+	return quantileMethodNames[m]
+}
+
+// ParseQuantileMethod - get the method for a name, either as returned by
+//  String or as the R type, "type1" to "type9". An empty name is NearestRank
+func ParseQuantileMethod(s string) (QuantileMethod, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return NearestRank, nil
+	}
+	for i, name := range quantileMethodNames {
+		if s == name || (i > 0 && s == fmt.Sprintf("type%d", i)) {
+			return QuantileMethod(i), nil
+		}
+	}
+	return NearestRank, fmt.Errorf("%w: %q", ErrUnknownMethod, s)
+}
+
+// fuzz - tolerance when finding the rank of a quantile, so a p such as 0.57
+//  (stored as a hair under) still lands on the rank it was written for. As
+//  R does, scaled to the magnitude of the position
+func fuzz(pos float64) float64 {
+	return 4 * 0x1p-52 * math.Max(1, math.Abs(pos))
+}
+
+// quantileIndex - the 0 based positions, in a sorted dataset of n numbers,
+//  of the order statistics either side of the pth quantile, and the weight
+//  given to the upper of them
+func quantileIndex(n int, p float64, method QuantileMethod) (int, int, float64) {
+	// clamp - convert a 1 based rank to a valid 0 based index
+	clamp := func(rank int) int {
+		if rank < 1 {
+			return 0
+		}
+		if rank > n {
+			return n - 1
+		}
+		return rank - 1
+	}
+
+	switch method {
+	case NearestRank:
+		j := clamp(int(math.Floor(float64(n)*p + fuzz(float64(n)*p))))
+		return j, j, 0
+
+	case InvertedCDF, AveragedInvertedCDF:
+		np := float64(n) * p
+		j := int(math.Floor(np + fuzz(np)))
+		if np-float64(j) > fuzz(np) {
+			return clamp(j + 1), clamp(j + 1), 0
+		}
+		if method == AveragedInvertedCDF {
+			return clamp(j), clamp(j + 1), 0.5
+		}
+		return clamp(j), clamp(j), 0
+
+	case ClosestObservation:
+		np := float64(n)*p - 0.5
+		j := int(math.Floor(np + fuzz(np)))
+		if np-float64(j) <= fuzz(np) && j%2 == 0 {
+			return clamp(j), clamp(j), 0
+		}
+		return clamp(j + 1), clamp(j + 1), 0
+	}
+
+	// the continuous methods differ only in the plotting position a + p(n+1-a-b)
+	var a, b float64
+	switch method {
+	case InterpolatedInvertedCDF:
+		a, b = 0, 1
+	case Hazen:
+		a, b = 0.5, 0.5
+	case Weibull:
+		a, b = 0, 0
+	case Linear:
+		a, b = 1, 1
+	case MedianUnbiased:
+		a, b = 1.0/3, 1.0/3
+	case NormalUnbiased:
+		a, b = 3.0/8, 3.0/8
+	}
+	pos := a + p*(float64(n)+1-a-b)
+	j := math.Floor(pos + fuzz(pos))
+	h := pos - j
+	if math.Abs(h) <= fuzz(pos) {
+		h = 0
+	}
+	return clamp(int(j)), clamp(int(j) + 1), h
+}
+
+// checkQuantile - validate the arguments common to the quantile functions
+func checkQuantile(n int, p float64, method QuantileMethod) error {
+	if n == 0 {
+		return ErrEmptyDataset
+	}
+	if !(p >= 0 && p <= 1) {
+		return fmt.Errorf("%w: quantile %v is not between 0 and 1", ErrQualifierOutOfRange, p)
+	}
+	if method < 0 || int(method) >= len(quantileMethodNames) {
+		return fmt.Errorf("%w: %v", ErrUnknownMethod, method)
+	}
+	return nil
+}
+
+// interpolate - the value frac of the way from low to high
+func interpolate(low, high, frac float64) float64 {
+	if frac == 0 || low == high {
+		return low
+	}
+	return low + frac*(high-low)
+}
+
+// Quantile - return the pth quantile (0 <= p <= 1, so 0.999 for the 99.9th
+//  percentile) of the provided numbers, using the given method. Only the
+//  one or two order statistics needed are found, with Select, so this is
+//  O(n) and nums is partially reordered in place, use QuantileCopy to leave
+//  it unchanged
+func Quantile[TN Number](nums []TN, p float64, method QuantileMethod) (float64, error) {
+	if err := checkQuantile(len(nums), p, method); err != nil {
+		return 0, err
+	}
+
+	lo, hi, frac := quantileIndex(len(nums), p, method)
+	low := Select(nums, lo)
+	if frac == 0 || hi == lo {
+		return float64(low), nil
+	}
+
+	// everything after lo is now no smaller, the next order statistic is the
+	// smallest of those
+	high := nums[lo+1]
+	for _, val := range nums[lo+2:] {
+		if val < high {
+			high = val
+		}
+	}
+	return interpolate(float64(low), float64(high), frac), nil
+}
+
+// QuantileCopy - as Quantile, but leaves nums unchanged. A copy of nums is
+//  made before selecting, so this allocates len(nums) elements on every call
+func QuantileCopy[TN Number](nums []TN, p float64, method QuantileMethod) (float64, error) {
+	return Quantile(clone(nums), p, method)
+}
+
+// Quantiles - return the quantile for each p in ps, as Quantile, in the
+//  same order as ps. nums is sorted once and every quantile read from it,
+//  so this is O(n log n) however many are asked for, rather than a pass per
+//  quantile. nums is sorted in place, use QuantilesCopy to leave it unchanged
+func Quantiles[TN Number](nums []TN, ps []float64, method QuantileMethod) ([]float64, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmptyDataset
+	}
+	for _, p := range ps {
+		if err := checkQuantile(len(nums), p, method); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+	answers := make([]float64, len(ps))
+	for i, p := range ps {
+		lo, hi, frac := quantileIndex(len(nums), p, method)
+		answers[i] = interpolate(float64(nums[lo]), float64(nums[hi]), frac)
+	}
+	return answers, nil
+}
+
+// QuantilesCopy - as Quantiles, but leaves nums unchanged. A copy of nums
+//  is made before sorting, so this allocates len(nums) elements on every call
+func QuantilesCopy[TN Number](nums []TN, ps []float64, method QuantileMethod) ([]float64, error) {
+	return Quantiles(clone(nums), ps, method)
+}
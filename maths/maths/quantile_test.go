@@ -0,0 +1,149 @@
+package maths
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// oneTo - the numbers 1 to n, in a scrambled order
+func oneTo(n int) []float64 {
+	nums := make([]float64, n)
+	for i := range nums {
+		nums[i] = float64((i*7919)%n + 1)
+	}
+	return nums
+}
+
+func TestQuantileMethods(t *testing.T) {
+	require := require.New(t)
+
+	// R: quantile(1:10, c(0, 0.25, 0.5, 0.9, 1), type = t)
+	probs := []float64{0, 0.25, 0.5, 0.9, 1}
+	testCases := map[QuantileMethod][]float64{
+		InvertedCDF:             {1, 3, 5, 9, 10},
+		AveragedInvertedCDF:     {1, 3, 5.5, 9.5, 10},
+		ClosestObservation:      {1, 2, 5, 9, 10},
+		InterpolatedInvertedCDF: {1, 2.5, 5, 9, 10},
+		Hazen:                   {1, 3, 5.5, 9.5, 10},
+		Weibull:                 {1, 2.75, 5.5, 9.9, 10},
+		Linear:                  {1, 3.25, 5.5, 9.1, 10},
+		MedianUnbiased:          {1, 2.9166666666666665, 5.5, 9.633333333333333, 10},
+		NormalUnbiased:          {1, 2.9375, 5.5, 9.6, 10},
+	}
+
+	for method, want := range testCases {
+		t.Run(method.String(), func(t *testing.T) {
+			for i, p := range probs {
+				got, err := Quantile(oneTo(10), p, method)
+				require.NoError(err, "should get the quantile")
+				require.InDelta(want[i], got, 1e-12, "should match R for p=%v", p)
+			}
+		})
+	}
+}
+
+func TestQuantileFractional(t *testing.T) {
+	require := require.New(t)
+
+	nums := oneTo(1000)
+	orig := clone(nums)
+	got, err := QuantileCopy(nums, 0.999, Linear)
+	require.NoError(err, "should get the 99.9th percentile")
+	require.InDelta(999.001, got, 1e-9, "should interpolate between the top values")
+	require.Equal(orig, nums, "QuantileCopy should not change the input")
+
+	got, err = Quantile([]int{1, 2, 3, 4}, 0.5, Linear)
+	require.NoError(err, "should get the median of ints")
+	require.Equal(2.5, got, "should interpolate between ints")
+
+	got, err = Quantile([]float64{4}, 0.3, NormalUnbiased)
+	require.NoError(err, "should get the quantile of a single value")
+	require.Equal(float64(4), got, "a single value is every quantile")
+}
+
+func TestQuantileNearestRank(t *testing.T) {
+	require := require.New(t)
+
+	// Quantile with NearestRank must keep giving what Percentile always has
+	for _, n := range []int{1, 3, 10, 20, 99, 100, 101} {
+		t.Run(fmt.Sprintf("%d numbers", n), func(t *testing.T) {
+			for q := 0; q <= 100; q++ {
+				got, err := Quantile(oneTo(n), float64(q)/100, NearestRank)
+				require.NoError(err, "should get the quantile")
+				require.Equal(Percentile(oneTo(n), q), got, "should match Percentile for q=%d", q)
+			}
+		})
+	}
+}
+
+func TestQuantileErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Quantile([]float64{}, 0.5, Linear)
+	require.ErrorIs(err, ErrEmptyDataset, "should reject an empty dataset")
+	_, err = Quantile([]float64{1}, 1.5, Linear)
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject p over 1")
+	_, err = Quantile([]float64{1}, -0.1, Linear)
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject negative p")
+	_, err = Quantile([]float64{1}, math.NaN(), Linear)
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject NaN p")
+	_, err = Quantile([]float64{1}, 0.5, QuantileMethod(10))
+	require.ErrorIs(err, ErrUnknownMethod, "should reject an unknown method")
+}
+
+func TestParseQuantileMethod(t *testing.T) {
+	require := require.New(t)
+
+	for i := NearestRank; i <= NormalUnbiased; i++ {
+		m, err := ParseQuantileMethod(i.String())
+		require.NoError(err, "should parse the name %q", i.String())
+		require.Equal(i, m, "should round trip the name")
+	}
+
+	m, err := ParseQuantileMethod("Type7")
+	require.NoError(err, "should parse the R type")
+	require.Equal(Linear, m, "type7 is linear")
+
+	m, err = ParseQuantileMethod("")
+	require.NoError(err, "should parse an empty name")
+	require.Equal(NearestRank, m, "no name is nearest rank")
+
+	_, err = ParseQuantileMethod("type0")
+	require.ErrorIs(err, ErrUnknownMethod, "should reject an unknown name")
+	_, err = ParseQuantileMethod("cubic")
+	require.ErrorIs(err, ErrUnknownMethod, "should reject an unknown name")
+}
+
+func TestQuantiles(t *testing.T) {
+	require := require.New(t)
+
+	ps := []float64{0.5, 0.9, 0.95, 0.99, 0.999, 0, 1}
+	for method := NearestRank; method <= NormalUnbiased; method++ {
+		t.Run(method.String(), func(t *testing.T) {
+			nums := oneTo(1000)
+			orig := clone(nums)
+
+			got, err := QuantilesCopy(nums, ps, method)
+			require.NoError(err, "should get the quantiles")
+			require.Equal(orig, nums, "QuantilesCopy should not change the input")
+			require.Len(got, len(ps), "should get a quantile for each p")
+			for i, p := range ps {
+				want, err := Quantile(oneTo(1000), p, method)
+				require.NoError(err, "should get the quantile")
+				require.Equal(want, got[i], "should match Quantile for p=%v", p)
+			}
+		})
+	}
+
+	got, err := Quantiles([]int{3, 1, 2}, []float64{}, Linear)
+	require.NoError(err, "should allow no quantiles")
+	require.Empty(got, "should get no quantiles")
+
+	_, err = Quantiles([]int{}, []float64{0.5}, Linear)
+	require.ErrorIs(err, ErrEmptyDataset, "should reject an empty dataset")
+	_, err = Quantiles([]int{3, 1, 2}, []float64{0.5, 2}, Linear)
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject any p out of range")
+}
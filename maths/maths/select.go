@@ -0,0 +1,178 @@
+package maths
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// insertionThreshold - ranges at or below this size are finished with an
+//  insertion sort, which beats partitioning on a handful of elements
+const insertionThreshold = 12
+
+// Select - return the kth smallest (0 based) of the provided numbers
+//  using introselect: quickselect with a median-of-three pivot and a Hoare
+//  partition, so sorted input and runs of repeated values both split evenly.
+//  If partitioning stops making progress the remaining range is sorted,
+//  which keeps the worst case at O(n log n) while the expected cost is O(n).
+//  nums is reordered in place so that nums[k] holds the result, everything
+//  before it is <= and everything after it is >=. Panics if k is not a
+//  valid index of nums
+func Select[TN Number](nums []TN, k int) TN {
+	if k < 0 || k >= len(nums) {
+		panic("maths: Select index out of range")
+	}
+
+	lo, hi := 0, len(nums)-1
+	depth := 2 * bits.Len(uint(len(nums)))
+	for hi > lo {
+		if hi-lo < insertionThreshold {
+			insertionSort(nums[lo : hi+1])
+			break
+		}
+		if depth == 0 {
+			sub := nums[lo : hi+1]
+			sort.Slice(sub, func(i, j int) bool { return sub[i] < sub[j] })
+			break
+		}
+		depth--
+
+		lt, gt := partition(nums, lo, hi)
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			// k landed between the two sides, on a value equal to the pivot
+			return nums[k]
+		}
+	}
+	return nums[k]
+}
+
+// partition - Hoare partition of nums[lo:hi+1] around a median-of-three
+//  pivot. On return nums[lo:lt] <= pivot, nums[gt+1:hi+1] >= pivot and
+//  anything between is equal to the pivot. Sorted input needs no swaps, and
+//  runs of repeated values are split evenly rather than all to one side
+func partition[TN Number](nums []TN, lo, hi int) (int, int) {
+	pivot := medianOfThree(nums[lo], nums[lo+(hi-lo)/2], nums[hi])
+
+	i, j := lo, hi
+	for i <= j {
+		for nums[i] < pivot {
+			i++
+		}
+		for nums[j] > pivot {
+			j--
+		}
+		if i <= j {
+			nums[i], nums[j] = nums[j], nums[i]
+			i++
+			j--
+		}
+	}
+	return j + 1, i - 1
+}
+
+// medianOfThree - return the middle value of a, b and c
+func medianOfThree[TN Number](a, b, c TN) TN {
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b = c
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// insertionSort - sort a small slice in ascending order
+func insertionSort[TN Number](nums []TN) {
+	for i := 1; i < len(nums); i++ {
+		for j := i; j > 0 && nums[j] < nums[j-1]; j-- {
+			nums[j], nums[j-1] = nums[j-1], nums[j]
+		}
+	}
+}
+
+// Smallest - return the k smallest numbers in ascending order, found with a
+//  bounded heap in O(n log k). nums is left unchanged, the result is a new
+//  slice of k elements. If k is greater than len(nums) every number is
+//  returned, sorted
+func Smallest[TN Number](nums []TN, k int) []TN {
+	return topK(nums, k, func(a, b TN) bool { return a < b })
+}
+
+// Largest - return the k largest numbers in descending order, found with a
+//  bounded heap in O(n log k). nums is left unchanged, the result is a new
+//  slice of k elements. If k is greater than len(nums) every number is
+//  returned, sorted
+func Largest[TN Number](nums []TN, k int) []TN {
+	return topK(nums, k, func(a, b TN) bool { return a > b })
+}
+
+// topK - keep the first k numbers according to before, in a heap whose root
+//  is the kept number that would come last, so each new number only has to
+//  beat the root to get in
+func topK[TN Number](nums []TN, k int, before func(a, b TN) bool) []TN {
+	if k > len(nums) {
+		k = len(nums)
+	}
+	if k <= 0 {
+		return []TN{}
+	}
+
+	// the root of the heap is the kept number furthest down the order
+	after := func(a, b TN) bool { return before(b, a) }
+
+	h := make([]TN, 0, k)
+	for _, val := range nums {
+		if len(h) < k {
+			h = append(h, val)
+			siftUp(h, len(h)-1, after)
+		} else if before(val, h[0]) {
+			h[0] = val
+			siftDown(h, 0, after)
+		}
+	}
+
+	// pop the root to the end of the heap until it is fully ordered
+	for end := len(h) - 1; end > 0; end-- {
+		h[0], h[end] = h[end], h[0]
+		siftDown(h[:end], 0, after)
+	}
+	return h
+}
+
+// siftUp - restore the heap property after adding the element at index i
+func siftUp[TN Number](h []TN, i int, higher func(a, b TN) bool) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !higher(h[i], h[parent]) {
+			return
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+	}
+}
+
+// siftDown - restore the heap property after replacing the element at index i
+func siftDown[TN Number](h []TN, i int, higher func(a, b TN) bool) {
+	for {
+		top := i
+		l, r := 2*i+1, 2*i+2
+		if l < len(h) && higher(h[l], h[top]) {
+			top = l
+		}
+		if r < len(h) && higher(h[r], h[top]) {
+			top = r
+		}
+		if top == i {
+			return
+		}
+		h[i], h[top] = h[top], h[i]
+		i = top
+	}
+}
@@ -0,0 +1,187 @@
+package maths
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the sort based implementations the selection engine replaced, kept as the
+// reference for the tests and the baseline for the benchmarks
+
+func sortMin[TN Number](nums []TN, qualifier int) []TN {
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	return nums[:qualifier]
+}
+
+func sortMax[TN Number](nums []TN, qualifier int) []TN {
+	sort.Slice(nums, func(i, j int) bool { return nums[i] > nums[j] })
+	return nums[:qualifier]
+}
+
+func sortMedian[TN Number](nums []TN) float64 {
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	m := len(nums)/2
+	if len(nums) % 2 != 0 {
+		return float64(nums[m])
+	}
+	return Avg(nums[m-1:m+1])
+}
+
+func sortPercentile[TN Number](nums []TN, q int) TN {
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	p := ((q * len(nums)) / 100) - 1
+	if p < 0 {
+		p = 0
+	}
+	return nums[p]
+}
+
+// datasets - inputs that exercise the different partitioning paths
+func datasets(n int) map[string][]float64 {
+	rnd := rand.New(rand.NewSource(int64(n)))
+
+	random := make([]float64, n)
+	repeats := make([]float64, n)
+	ascending := make([]float64, n)
+	descending := make([]float64, n)
+	constant := make([]float64, n)
+	for i := range random {
+		random[i] = rnd.NormFloat64() * 1000
+		repeats[i] = float64(rnd.Intn(5))
+		ascending[i] = float64(i)
+		descending[i] = float64(n - i)
+		constant[i] = 7
+	}
+
+	return map[string][]float64{
+		"random":     random,
+		"repeats":    repeats,
+		"ascending":  ascending,
+		"descending": descending,
+		"constant":   constant,
+	}
+}
+
+func TestSelect(t *testing.T) {
+	require := require.New(t)
+
+	for _, n := range []int{1, 2, 5, 13, 100, 1001} {
+		for dn, data := range datasets(n) {
+			t.Run(fmt.Sprintf("%s of %d", dn, n), func(t *testing.T) {
+				sorted := clone(data)
+				sort.Float64s(sorted)
+
+				for _, k := range []int{0, n/4, n/2, n-1} {
+					nums := clone(data)
+					require.Equal(sorted[k], Select(nums, k), "should select the kth smallest")
+					for i := range nums {
+						if i < k {
+							require.LessOrEqual(nums[i], nums[k], "values before k should be no greater")
+						} else if i > k {
+							require.GreaterOrEqual(nums[i], nums[k], "values after k should be no less")
+						}
+					}
+				}
+			})
+		}
+	}
+
+	require.Panics(func() { Select([]int{}, 0) }, "should panic selecting from an empty list")
+	require.Panics(func() { Select([]int{1,2}, 2) }, "should panic selecting past the end")
+}
+
+func TestSmallestLargest(t *testing.T) {
+	require := require.New(t)
+
+	for _, n := range []int{1, 7, 100, 1001} {
+		for dn, data := range datasets(n) {
+			t.Run(fmt.Sprintf("%s of %d", dn, n), func(t *testing.T) {
+				orig := clone(data)
+
+				for _, k := range []int{1, 3, n/2, n, n+1} {
+					want := k
+					if want > n {
+						want = n
+					}
+					require.Equal(sortMin(clone(data), want), Smallest(data, k), "should get the smallest k ascending")
+					require.Equal(sortMax(clone(data), want), Largest(data, k), "should get the largest k descending")
+				}
+				require.Equal(orig, data, "should leave the input unchanged")
+			})
+		}
+	}
+
+	require.Equal([]int{}, Smallest([]int{3,1,2}, 0), "should return nothing for k of 0")
+	require.Equal([]int{}, Largest([]int{}, 2), "should return nothing for an empty list")
+}
+
+func TestSelectMatchesSort(t *testing.T) {
+	require := require.New(t)
+
+	for dn, data := range datasets(1000) {
+		t.Run(dn, func(t *testing.T) {
+			require.Equal(sortMedian(clone(data)), Median(clone(data)), "median should match the sort based median")
+			require.Equal(sortMedian(clone(data[1:])), Median(clone(data[1:])), "median of an odd length should match")
+			for q := 0; q <= 100; q++ {
+				require.Equal(sortPercentile(clone(data), q), Percentile(clone(data), q), "percentile %d should match", q)
+			}
+		})
+	}
+}
+
+// benchSizes - dataset sizes for comparing the selection engine with sorting
+var benchSizes = []int{1000, 100000, 1000000}
+
+func benchmarkPair(b *testing.B, selectFn, sortFn func([]float64)) {
+	for _, n := range benchSizes {
+		data := datasets(n)["random"]
+		nums := make([]float64, n)
+
+		for _, bc := range []struct{
+			name string
+			fn   func([]float64)
+		}{{"select", selectFn}, {"sort", sortFn}} {
+			fn := bc.fn
+			b.Run(fmt.Sprintf("%s/%d", bc.name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					copy(nums, data)
+					b.StartTimer()
+					fn(nums)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkMin(b *testing.B) {
+	benchmarkPair(b,
+		func(nums []float64) { Min(nums, 10) },
+		func(nums []float64) { sortMin(nums, 10) },
+	)
+}
+
+func BenchmarkMax(b *testing.B) {
+	benchmarkPair(b,
+		func(nums []float64) { Max(nums, 10) },
+		func(nums []float64) { sortMax(nums, 10) },
+	)
+}
+
+func BenchmarkMedian(b *testing.B) {
+	benchmarkPair(b,
+		func(nums []float64) { Median(nums) },
+		func(nums []float64) { sortMedian(nums) },
+	)
+}
+
+func BenchmarkPercentile(b *testing.B) {
+	benchmarkPair(b,
+		func(nums []float64) { Percentile(nums, 99) },
+		func(nums []float64) { sortPercentile(nums, 99) },
+	)
+}
@@ -0,0 +1,118 @@
+package maths
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// isFloat - report whether TN is one of the floating point types
+func isFloat[TN Number]() bool {
+	var half TN = 1
+	half /= 2
+	return half != 0
+}
+
+// Sum - the sum of the provided numbers. Integers are added in an int64,
+//  carrying on in a big.Int should that overflow, so the only rounding is
+//  the final conversion to float64. Floats use Neumaier's compensated
+//  summation, so the low order bits a running total drops on each addition
+//  are kept and added back at the end
+func Sum[TN Number](nums []TN) float64 {
+	if isFloat[TN]() {
+		return floatSum(nums)
+	}
+	return intSum(nums)
+}
+
+// intSum - exact sum of integers, widened to int64 and checked for overflow
+func intSum[TN Number](nums []TN) float64 {
+	var total int64
+	for i, val := range nums {
+		v := int64(val)
+		next := total + v
+		if (v > 0 && next < total) || (v < 0 && next > total) {
+			// too big for an int64, finish the sum in a big.Int
+			acc := big.NewInt(total)
+			for _, rest := range nums[i:] {
+				acc.Add(acc, big.NewInt(int64(rest)))
+			}
+			f, _ := new(big.Float).SetInt(acc).Float64()
+			return f
+		}
+		total = next
+	}
+	return float64(total)
+}
+
+// floatSum - Neumaier compensated sum of floats, in float64 so float32
+//  inputs are widened without loss
+func floatSum[TN Number](nums []TN) float64 {
+	var sum neumaier
+	for _, val := range nums {
+		sum.add(float64(val))
+	}
+	return sum.value()
+}
+
+// neumaier - a running compensated sum, the total so far and the low order
+//  bits lost from it along the way
+type neumaier struct {
+	sum  float64
+	comp float64
+}
+
+// add - add v to the running sum
+func (n *neumaier) add(v float64) {
+	t := n.sum + v
+	// whichever operand is smaller in magnitude lost bits to the addition
+	if math.Abs(n.sum) >= math.Abs(v) {
+		n.comp += (n.sum - t) + v
+	} else {
+		n.comp += (v - t) + n.sum
+	}
+	n.sum = t
+}
+
+// value - the compensated sum
+func (n neumaier) value() float64 {
+	// once infinite the compensation is meaningless (inf - inf is NaN)
+	if math.IsInf(n.sum, 0) {
+		return n.sum
+	}
+	return n.sum + n.comp
+}
+
+// SumExact - the exact sum of the provided numbers as a rational, for when
+//  the answer has to be audited rather than just be close. Every float is
+//  exactly representable as a big.Rat, so nothing is rounded. Costs an
+//  allocation per number, so is much slower than Sum
+func SumExact[TN Number](nums []TN) (*big.Rat, error) {
+	sum := new(big.Rat)
+	val := new(big.Rat)
+	for i, n := range nums {
+		if isFloat[TN]() {
+			if val.SetFloat64(float64(n)) == nil {
+				return nil, fmt.Errorf("%w: element %d is %v", ErrNonFinite, i, n)
+			}
+		} else {
+			val.SetInt64(int64(n))
+		}
+		sum.Add(sum, val)
+	}
+	return sum, nil
+}
+
+// AvgExact - the exact mean of the provided numbers as a rational, see
+//  SumExact. Use its Float64 method for the nearest float64 to the answer
+func AvgExact[TN Number](nums []TN) (*big.Rat, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	sum, err := SumExact(nums)
+	if err != nil {
+		return nil, err
+	}
+	return sum.Quo(sum, new(big.Rat).SetInt64(int64(len(nums)))), nil
+}
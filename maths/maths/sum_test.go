@@ -0,0 +1,135 @@
+package maths
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// naiveAvg - the original Avg, accumulating in the element type
+func naiveAvg[TN Number](nums []TN) float64 {
+	var sum TN = 0
+	for _, val := range nums {
+		sum += val
+	}
+	return float64(sum)/float64(len(nums))
+}
+
+// repeat - n copies of val
+func repeat[TN Number](val TN, n int) []TN {
+	nums := make([]TN, n)
+	for i := range nums {
+		nums[i] = val
+	}
+	return nums
+}
+
+func TestAvgAccuracy(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct{
+		input interface{}
+		naive float64
+		avg   float64
+	}{
+		"int8 overflow": {
+			input: []int8{100,100},
+			naive: -28,
+			avg:   100,
+		},
+		"int8 underflow": {
+			input: []int8{-100,-100,-100},
+			naive: float64(int8(-300+256))/3,
+			avg:   -100,
+		},
+		"int32 overflow": {
+			input: []int32{math.MaxInt32,math.MaxInt32,math.MaxInt32,math.MaxInt32},
+			naive: -1,
+			avg:   math.MaxInt32,
+		},
+		"int64 overflow": {
+			input: []int64{math.MaxInt64,math.MaxInt64},
+			naive: -1,
+			avg:   math.MaxInt64,
+		},
+		"int64 overflow by one": {
+			input: []int64{math.MaxInt64,1},
+			naive: math.MinInt64/2,
+			avg:   -(math.MinInt64/2),
+		},
+		"float64 cancellation": {
+			input: []float64{1e16,1,-1e16},
+			naive: 0,
+			avg:   float64(1)/3,
+		},
+		"float64 small terms lost": {
+			input: []float64{1,1e100,1,-1e100},
+			naive: 0,
+			avg:   0.5,
+		},
+		"float32 long series": {
+			input: repeat(float32(0.1), 1000000),
+			naive: float64(float32(100958.34))/1000000,
+			avg:   float64(float32(0.1)),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var naive, avg float64
+			var exact *big.Rat
+			var err error
+			switch inp := tc.input.(type) {
+			case []int8:
+				naive, avg = naiveAvg(inp), Avg(inp)
+				exact, err = AvgExact(inp)
+			case []int32:
+				naive, avg = naiveAvg(inp), Avg(inp)
+				exact, err = AvgExact(inp)
+			case []int64:
+				naive, avg = naiveAvg(inp), Avg(inp)
+				exact, err = AvgExact(inp)
+			case []float32:
+				naive, avg = naiveAvg(inp), Avg(inp)
+				exact, err = AvgExact(inp)
+			case []float64:
+				naive, avg = naiveAvg(inp), Avg(inp)
+				exact, err = AvgExact(inp)
+			default:
+				require.FailNow("Unhandled input type provided")
+			}
+
+			require.Equal(tc.naive, naive, "the original implementation gets the wrong answer")
+			require.Equal(tc.avg, avg, "Avg should get the right answer")
+			require.NoError(err, "AvgExact should not error")
+			f, _ := exact.Float64()
+			require.Equal(tc.avg, f, "AvgExact should agree with Avg")
+		})
+	}
+}
+
+func TestSumExact(t *testing.T) {
+	require := require.New(t)
+
+	sum, err := SumExact([]float64{0.1, 0.2})
+	require.NoError(err, "should sum finite values")
+	want := new(big.Rat).Add(new(big.Rat).SetFloat64(0.1), new(big.Rat).SetFloat64(0.2))
+	require.Equal(0, want.Cmp(sum), "should keep every bit of both values")
+	require.NotEqual(0, new(big.Rat).SetFloat64(0.1+0.2).Cmp(sum), "should differ from the rounded float sum")
+
+	sum, err = SumExact([]int{})
+	require.NoError(err, "should sum an empty dataset")
+	require.Equal(0, sum.Sign(), "empty sum should be 0")
+
+	_, err = SumExact([]float64{1, math.NaN()})
+	require.ErrorIs(err, ErrNonFinite, "should reject NaN")
+	_, err = AvgExact([]float32{float32(math.Inf(1))})
+	require.ErrorIs(err, ErrNonFinite, "should reject infinity")
+	_, err = AvgExact([]int{})
+	require.ErrorIs(err, ErrEmptyDataset, "should reject an empty dataset")
+
+	require.True(math.IsInf(Sum([]float64{1, math.Inf(1), 2}), 1), "infinity should survive compensation")
+	require.True(math.IsNaN(Sum([]float64{1, math.NaN()})), "NaN should survive compensation")
+}
@@ -0,0 +1,317 @@
+package maths
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// tdigestVersion - the version of the binary encoding written by MarshalBinary
+const tdigestVersion = 1
+
+// TDigest - an approximate quantile sketch (Dunning & Ertl's merging
+//  t-digest) for streams too large to hold and sort. Numbers are summarised
+//  as weighted centroids, kept small near the tails and larger towards the
+//  median, so extreme quantiles such as p99.9 stay accurate. Memory is fixed
+//  by the compression, not by how many numbers are added.
+//  A TDigest is not safe for concurrent use, give each goroutine or shard
+//  its own and combine them with Merge
+type TDigest struct {
+	compression float64
+	centroids   []centroid // merged, in order of mean
+	buffer      []centroid // added since the last merge, in no order
+	count       float64    // total weight of centroids and buffer
+	min         float64
+	max         float64
+}
+
+// centroid - the mean of a cluster of numbers, and how many there were
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// NewTDigest - create an empty t-digest. The compression trades memory for
+//  accuracy: a digest holds at most about compression centroids, and the
+//  quantile error is roughly proportional to q(1-q)/compression. 100 gives
+//  rank errors well under 1%, 200 to 1000 is usual when tails matter most
+func NewTDigest(compression float64) (*TDigest, error) {
+	if !(compression >= 10) || math.IsInf(compression, 0) {
+		return nil, fmt.Errorf("%w: compression %v must be at least 10", ErrQualifierOutOfRange, compression)
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}, nil
+}
+
+// Compression - the compression the digest was created with
+func (t *TDigest) Compression() float64 {
+	return t.compression
+}
+
+// Count - the number of numbers added to the digest
+func (t *TDigest) Count() int {
+	return int(t.count)
+}
+
+// Add - add a number to the digest. NaN and infinities are rejected, as
+//  they cannot be placed on the distribution
+func (t *TDigest) Add(val float64) error {
+	if math.IsNaN(val) || math.IsInf(val, 0) {
+		return fmt.Errorf("%w: cannot add %v", ErrNonFinite, val)
+	}
+	t.add(centroid{mean: val, weight: 1})
+	return nil
+}
+
+// add - buffer a centroid, merging once the buffer is full
+func (t *TDigest) add(c centroid) {
+	t.buffer = append(t.buffer, c)
+	t.count += c.weight
+	if c.mean < t.min {
+		t.min = c.mean
+	}
+	if c.mean > t.max {
+		t.max = c.mean
+	}
+	if len(t.buffer) >= t.bufferSize() {
+		t.flush()
+	}
+}
+
+// bufferSize - how many centroids are buffered before a merge
+func (t *TDigest) bufferSize() int {
+	return int(5 * t.compression)
+}
+
+// Merge - add everything in b to the digest, as if its numbers had been
+//  added directly. b is left unchanged, and need not share the compression
+func (t *TDigest) Merge(b *TDigest) {
+	if b == nil {
+		return
+	}
+	// copy first, adding may flush, which would reuse b's buffer if b is t
+	incoming := make([]centroid, 0, len(b.centroids)+len(b.buffer))
+	incoming = append(incoming, b.centroids...)
+	incoming = append(incoming, b.buffer...)
+	for _, c := range incoming {
+		t.add(c)
+	}
+	// add only sees b's centroid means, not the exact extremes they hide
+	if b.min < t.min {
+		t.min = b.min
+	}
+	if b.max > t.max {
+		t.max = b.max
+	}
+}
+
+// k - the scale function, mapping a quantile to the index of the centroid
+//  holding it. Its slope is steep near 0 and 1, so centroids there are small
+func (t *TDigest) k(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// kInverse - the quantile at centroid index k
+func (t *TDigest) kInverse(k float64) float64 {
+	if k >= t.compression/4 {
+		return 1
+	}
+	return (math.Sin(2*math.Pi*k/t.compression) + 1) / 2
+}
+
+// flush - merge the buffer into the centroids. Working in order of mean, a
+//  centroid absorbs its neighbour for as long as the result spans no more
+//  than one unit of k
+func (t *TDigest) flush() {
+	if len(t.buffer) == 0 {
+		return
+	}
+
+	all := make([]centroid, 0, len(t.centroids)+len(t.buffer))
+	all = append(all, t.centroids...)
+	all = append(all, t.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := make([]centroid, 0, len(t.centroids)+1)
+	cur := all[0]
+	before := 0.0
+	limit := t.kInverse(t.k(0) + 1)
+	for _, c := range all[1:] {
+		if (before+cur.weight+c.weight)/t.count <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		merged = append(merged, cur)
+		before += cur.weight
+		limit = t.kInverse(t.k(before/t.count) + 1)
+		cur = c
+	}
+	merged = append(merged, cur)
+
+	t.centroids = merged
+	t.buffer = t.buffer[:0]
+}
+
+// Quantile - the approximate pth quantile (0 <= p <= 1) of the numbers
+//  added, interpolating between the centres of the centroids either side,
+//  and towards the exact min and max at the ends
+func (t *TDigest) Quantile(p float64) (float64, error) {
+	if t.count == 0 {
+		return 0, ErrEmptyDataset
+	}
+	if !(p >= 0 && p <= 1) {
+		return 0, fmt.Errorf("%w: quantile %v is not between 0 and 1", ErrQualifierOutOfRange, p)
+	}
+	t.flush()
+
+	c := t.centroids
+	if len(c) == 1 || t.min == t.max {
+		return interpolate(t.min, t.max, p), nil
+	}
+
+	index := p * t.count
+	first, last := c[0], c[len(c)-1]
+	if index <= first.weight/2 {
+		return interpolate(t.min, first.mean, index/(first.weight/2)), nil
+	}
+	if index >= t.count-last.weight/2 {
+		return interpolate(last.mean, t.max, 1-(t.count-index)/(last.weight/2)), nil
+	}
+
+	// walk the centres of the centroids until index falls between two
+	centre := first.weight / 2
+	for i := 0; i < len(c)-1; i++ {
+		gap := (c[i].weight + c[i+1].weight) / 2
+		if centre+gap > index {
+			return interpolate(c[i].mean, c[i+1].mean, (index-centre)/gap), nil
+		}
+		centre += gap
+	}
+	return last.mean, nil
+}
+
+// CDF - the approximate fraction of the numbers added that are <= x, the
+//  inverse of Quantile
+func (t *TDigest) CDF(x float64) (float64, error) {
+	if t.count == 0 {
+		return 0, ErrEmptyDataset
+	}
+	if math.IsNaN(x) {
+		return 0, fmt.Errorf("%w: cannot place %v", ErrNonFinite, x)
+	}
+	t.flush()
+
+	if x < t.min {
+		return 0, nil
+	}
+	if x >= t.max {
+		return 1, nil
+	}
+
+	c := t.centroids
+	first, last := c[0], c[len(c)-1]
+	if len(c) == 1 {
+		return (x - t.min) / (t.max - t.min), nil
+	}
+	if x < first.mean {
+		return first.weight / 2 * (x - t.min) / (first.mean - t.min) / t.count, nil
+	}
+	if x >= last.mean {
+		return 1 - last.weight/2*(t.max-x)/(t.max-last.mean)/t.count, nil
+	}
+
+	centre := first.weight / 2
+	for i := 0; i < len(c)-1; i++ {
+		gap := (c[i].weight + c[i+1].weight) / 2
+		if x < c[i+1].mean {
+			return (centre + gap*(x-c[i].mean)/(c[i+1].mean-c[i].mean)) / t.count, nil
+		}
+		centre += gap
+	}
+	return 1, nil
+}
+
+// tdigestHeader - the fixed part of the binary encoding
+type tdigestHeader struct {
+	Version     uint8
+	Compression float64
+	Min         float64
+	Max         float64
+	Centroids   uint32
+}
+
+// MarshalBinary - encode the digest, so it can be stored or sent and merged
+//  elsewhere. Big endian, a header followed by the mean and weight of each
+//  centroid
+func (t *TDigest) MarshalBinary() ([]byte, error) {
+	t.flush()
+
+	buf := &bytes.Buffer{}
+	header := tdigestHeader{
+		Version:     tdigestVersion,
+		Compression: t.compression,
+		Min:         t.min,
+		Max:         t.max,
+		Centroids:   uint32(len(t.centroids)),
+	}
+	if err := binary.Write(buf, binary.BigEndian, &header); err != nil {
+		return nil, err
+	}
+	for _, c := range t.centroids {
+		if err := binary.Write(buf, binary.BigEndian, [2]float64{c.mean, c.weight}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary - restore a digest encoded by MarshalBinary
+func (t *TDigest) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	header := tdigestHeader{}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return fmt.Errorf("%w: t-digest header: %v", ErrInvalidState, err)
+	}
+	if header.Version != tdigestVersion {
+		return fmt.Errorf("%w: t-digest version %d is not supported", ErrInvalidState, header.Version)
+	}
+	if !(header.Compression >= 10) || math.IsInf(header.Compression, 0) {
+		return fmt.Errorf("%w: t-digest compression %v", ErrInvalidState, header.Compression)
+	}
+	if int64(r.Len()) != int64(header.Centroids)*16 {
+		return fmt.Errorf("%w: t-digest has %d bytes of centroids, expected %d", ErrInvalidState, r.Len(), header.Centroids*16)
+	}
+
+	restored := TDigest{
+		compression: header.Compression,
+		centroids:   make([]centroid, header.Centroids),
+		min:         header.Min,
+		max:         header.Max,
+	}
+	prev := header.Min
+	for i := range restored.centroids {
+		var pair [2]float64
+		if err := binary.Read(r, binary.BigEndian, &pair); err != nil {
+			return fmt.Errorf("%w: t-digest centroid %d: %v", ErrInvalidState, i, err)
+		}
+		c := centroid{mean: pair[0], weight: pair[1]}
+		if !(c.weight > 0) || !(c.mean >= prev) || !(c.mean <= header.Max) {
+			return fmt.Errorf("%w: t-digest centroid %d is out of order", ErrInvalidState, i)
+		}
+		restored.centroids[i] = c
+		restored.count += c.weight
+		prev = c.mean
+	}
+	if restored.count == 0 {
+		restored.min, restored.max = math.Inf(1), math.Inf(-1)
+	}
+
+	*t = restored
+	return nil
+}
@@ -0,0 +1,213 @@
+package maths
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// distributions - generated datasets with different shapes
+func distributions(n int) map[string][]float64 {
+	rnd := rand.New(rand.NewSource(9))
+
+	uniform := make([]float64, n)
+	normal := make([]float64, n)
+	exponential := make([]float64, n)
+	latency := make([]float64, n)
+	for i := 0; i < n; i++ {
+		uniform[i] = rnd.Float64() * 1000
+		normal[i] = rnd.NormFloat64()*15 + 100
+		exponential[i] = rnd.ExpFloat64() * 40
+		// mostly fast, with a long slow tail
+		latency[i] = math.Exp(rnd.NormFloat64()*0.8 + 3)
+	}
+
+	return map[string][]float64{
+		"uniform":     uniform,
+		"normal":      normal,
+		"exponential": exponential,
+		"latency":     latency,
+	}
+}
+
+// requireRankError - check every percentile of the digest lands within
+//  tolerance of the exact Percentile, measured in rank: the estimate for q
+//  must lie between the exact (q-tolerance)th and (q+tolerance)th percentiles
+func requireRankError(require *require.Assertions, sorted []float64, td *TDigest, tolerance int) {
+	for q := 1; q < 100; q++ {
+		lowQ, highQ := q-tolerance, q+tolerance
+		if lowQ < 0 {
+			lowQ = 0
+		}
+		if highQ > 100 {
+			highQ = 100
+		}
+		low, high := Percentile(sorted, lowQ), Percentile(sorted, highQ)
+
+		est, err := td.Quantile(float64(q) / 100)
+		require.NoError(err, "should estimate the quantile")
+		require.GreaterOrEqual(est, low, "p%d should be no lower than the exact p%d", q, lowQ)
+		require.LessOrEqual(est, high, "p%d should be no higher than the exact p%d", q, highQ)
+
+		cdf, err := td.CDF(Percentile(sorted, q))
+		require.NoError(err, "should estimate the CDF")
+		require.InDelta(float64(q)/100, cdf, float64(tolerance)/100, "CDF at the exact p%d should be close to %d%%", q, q)
+	}
+}
+
+func TestTDigest(t *testing.T) {
+	require := require.New(t)
+
+	for dn, data := range distributions(100000) {
+		t.Run(dn, func(t *testing.T) {
+			td, err := NewTDigest(100)
+			require.NoError(err, "should create the digest")
+			for _, val := range data {
+				require.NoError(td.Add(val), "should add the number")
+			}
+			require.Equal(len(data), td.Count(), "should count every number")
+
+			sorted := clone(data)
+			sort.Float64s(sorted)
+			requireRankError(require, sorted, td, 1)
+
+			// the extremes are exact, and the far tails close
+			min, err := td.Quantile(0)
+			require.NoError(err, "should get the min")
+			require.Equal(sorted[0], min, "p0 should be the min")
+			max, err := td.Quantile(1)
+			require.NoError(err, "should get the max")
+			require.Equal(sorted[len(sorted)-1], max, "p100 should be the max")
+			p999, err := td.Quantile(0.999)
+			require.NoError(err, "should get p99.9")
+			require.GreaterOrEqual(p999, sorted[99850], "p99.9 should be within 0.05%% rank")
+			require.LessOrEqual(p999, sorted[99950], "p99.9 should be within 0.05%% rank")
+
+			require.LessOrEqual(len(td.centroids), 100, "should stay within the compression")
+		})
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	require := require.New(t)
+
+	data := distributions(100000)["latency"]
+	sorted := clone(data)
+	sort.Float64s(sorted)
+
+	shards := make([]*TDigest, 8)
+	for i := range shards {
+		shards[i], _ = NewTDigest(100)
+	}
+	for i, val := range data {
+		require.NoError(shards[i%len(shards)].Add(val), "should add the number")
+	}
+
+	merged, _ := NewTDigest(100)
+	for _, shard := range shards {
+		merged.Merge(shard)
+	}
+	merged.Merge(nil)
+	require.Equal(len(data), merged.Count(), "should count every number")
+	requireRankError(require, sorted, merged, 1)
+	for q, want := range map[float64]float64{0: sorted[0], 1: sorted[len(sorted)-1]} {
+		got, err := merged.Quantile(q)
+		require.NoError(err, "should get the quantile")
+		require.Equal(want, got, "should keep the exact extremes of every shard, q=%v", q)
+	}
+
+	// merging with itself doubles every weight, and leaves the shape alone
+	merged.Merge(merged)
+	require.Equal(2*len(data), merged.Count(), "should count every number twice")
+	requireRankError(require, sorted, merged, 1)
+}
+
+func TestTDigestSmall(t *testing.T) {
+	require := require.New(t)
+
+	td, _ := NewTDigest(100)
+	_, err := td.Quantile(0.5)
+	require.ErrorIs(err, ErrEmptyDataset, "should have no quantiles when empty")
+	_, err = td.CDF(1)
+	require.ErrorIs(err, ErrEmptyDataset, "should have no CDF when empty")
+
+	require.NoError(td.Add(5), "should add a number")
+	got, err := td.Quantile(0.3)
+	require.NoError(err, "should get the quantile of one number")
+	require.Equal(float64(5), got, "one number is every quantile")
+
+	for _, val := range []float64{1, 2, 3, 4} {
+		require.NoError(td.Add(val), "should add a number")
+	}
+	for q, want := range map[float64]float64{0: 1, 0.5: 3, 1: 5} {
+		got, err := td.Quantile(q)
+		require.NoError(err, "should get the quantile")
+		require.Equal(want, got, "a small digest is exact at its centroids, q=%v", q)
+	}
+	cdf, err := td.CDF(0)
+	require.NoError(err, "should get the CDF below the min")
+	require.Equal(float64(0), cdf, "nothing is below the min")
+	cdf, err = td.CDF(5)
+	require.NoError(err, "should get the CDF at the max")
+	require.Equal(float64(1), cdf, "everything is at or below the max")
+}
+
+func TestTDigestErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewTDigest(5)
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject a tiny compression")
+	_, err = NewTDigest(math.NaN())
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject a NaN compression")
+
+	td, _ := NewTDigest(50)
+	require.ErrorIs(td.Add(math.NaN()), ErrNonFinite, "should reject NaN")
+	require.ErrorIs(td.Add(math.Inf(-1)), ErrNonFinite, "should reject infinity")
+	require.Equal(0, td.Count(), "should not count rejected numbers")
+
+	require.NoError(td.Add(1), "should add a number")
+	_, err = td.Quantile(1.01)
+	require.ErrorIs(err, ErrQualifierOutOfRange, "should reject q over 1")
+	_, err = td.CDF(math.NaN())
+	require.ErrorIs(err, ErrNonFinite, "should reject a NaN CDF")
+}
+
+func TestTDigestBinary(t *testing.T) {
+	require := require.New(t)
+
+	td, _ := NewTDigest(200)
+	for _, val := range distributions(20000)["exponential"] {
+		require.NoError(td.Add(val), "should add the number")
+	}
+
+	data, err := td.MarshalBinary()
+	require.NoError(err, "should encode the digest")
+	restored := &TDigest{}
+	require.NoError(restored.UnmarshalBinary(data), "should decode the digest")
+	require.Equal(td.Count(), restored.Count(), "should restore the count")
+	require.Equal(td.Compression(), restored.Compression(), "should restore the compression")
+	for q := 0; q <= 100; q++ {
+		want, _ := td.Quantile(float64(q) / 100)
+		got, err := restored.Quantile(float64(q) / 100)
+		require.NoError(err, "should get the restored quantile")
+		require.Equal(want, got, "should give the same p%d", q)
+	}
+
+	empty, _ := NewTDigest(100)
+	data, err = empty.MarshalBinary()
+	require.NoError(err, "should encode an empty digest")
+	require.NoError(restored.UnmarshalBinary(data), "should decode an empty digest")
+	require.Equal(0, restored.Count(), "should restore an empty digest")
+	require.NoError(restored.Add(3), "should add to a restored empty digest")
+
+	data, _ = td.MarshalBinary()
+	require.ErrorIs(restored.UnmarshalBinary(data[:10]), ErrInvalidState, "should reject a truncated header")
+	require.ErrorIs(restored.UnmarshalBinary(data[:len(data)-1]), ErrInvalidState, "should reject truncated centroids")
+	bad := append([]byte{}, data...)
+	bad[0] = 99
+	require.ErrorIs(restored.UnmarshalBinary(bad), ErrInvalidState, "should reject an unknown version")
+	require.Equal(1, restored.Count(), "a failed decode should leave the digest alone")
+}
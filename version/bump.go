@@ -0,0 +1,131 @@
+package version
+
+import (
+	"strings"
+)
+
+// BumpLevel - return the next version at level i (0 based), with level i
+//  incremented and the levels after it zeroed, eg level 1 of 1.2.3.4 is
+//  1.3.0.0. A version shorter than i+1 levels is padded with zeros, so
+//  level 2 of 1.2 is 1.2.1.
+//  A pre-release is before its release, so if the levels after i are all
+//  zero the next version is just the release, eg level 1 of 1.2.0-rc.1 is
+//  1.2.0 while level 1 of 1.2.3-rc.1 is 1.3.0. A level the pre-release does
+//  not have is padded and incremented as for a release, so level 3 of
+//  1.2.3-rc.1 is 1.2.3.1, as 1.2.3.0 would not be its release when compared
+//  Strictly.
+//  Build metadata is dropped, the policy is kept.
+//  If index is negative it returns an error
+func (v Version) BumpLevel(i int) (*Version, error) {
+	if i < 0 {
+		return nil, InvalidIndex
+	}
+
+	levels := append([]string{}, v.asArray...)
+	for len(levels) <= i {
+		levels = append(levels, "0")
+	}
+
+	released := len(v.pre) > 0 && i < v.Len()
+	for _, val := range levels[i+1:] {
+		if val != "0" {
+			released = false
+		}
+	}
+	if !released {
+		levels[i] = incrementDigits(levels[i])
+		for j := i + 1; j < len(levels); j++ {
+			levels[j] = "0"
+		}
+	}
+	return v.withLevels(levels, nil), nil
+}
+
+// NextMajor - return the next major version, level 0, see BumpLevel
+func (v Version) NextMajor() *Version {
+	next, _ := v.BumpLevel(0)
+	return next
+}
+
+// NextMinor - return the next minor version, level 1, see BumpLevel
+func (v Version) NextMinor() *Version {
+	next, _ := v.BumpLevel(1)
+	return next
+}
+
+// NextPatch - return the next patch version, level 2, see BumpLevel
+func (v Version) NextPatch() *Version {
+	next, _ := v.BumpLevel(2)
+	return next
+}
+
+// NextPrerelease - return the next pre-release of a SemVer version.
+//  - a pre-release with the same id, or no id, has its last numeric
+//    identifier incremented, or .0 added if it has none, eg 1.0.0-rc.1
+//    is 1.0.0-rc.2 and 1.0.0-rc is 1.0.0-rc.0
+//  - a pre-release with a different id starts again at id.0, eg 1.0.0-alpha.3
+//    with id beta is 1.0.0-beta.0, which is before the version if beta
+//    sorts before the current pre-release
+//  - a release is bumped to the next patch, as id.0, or 0 if there is no
+//    id, eg 1.2.3 with id rc is 1.2.4-rc.0
+//    Build metadata is dropped, the policy is kept.
+//    Returns an error if the version does not have 3 levels, or id is not
+//    made of valid pre-release identifiers
+func (v Version) NextPrerelease(id string) (*Version, error) {
+	if v.Len() != 3 {
+		return nil, InvalidSemVer
+	}
+	ids := []string{}
+	if id != "" {
+		ids = strings.Split(id, ".")
+		for _, str := range ids {
+			if !isIdentifier(str) || (len(str) > 1 && str[0] == '0' && isNumeric(str)) {
+				return nil, InvalidPrerelease
+			}
+		}
+	}
+
+	levels := append([]string{}, v.asArray...)
+	if len(v.pre) == 0 {
+		levels[2] = incrementDigits(levels[2])
+		return v.withLevels(levels, append(ids, "0")), nil
+	}
+
+	pre := append([]string{}, v.pre...)
+	if id != "" && !strings.HasPrefix(v.Prerelease()+".", id+".") {
+		return v.withLevels(levels, append(ids, "0")), nil
+	}
+	for i := len(pre) - 1; i >= 0; i-- {
+		if isNumeric(pre[i]) {
+			pre[i] = incrementDigits(pre[i])
+			return v.withLevels(levels, pre), nil
+		}
+	}
+	return v.withLevels(levels, append(pre, "0")), nil
+}
+
+// incrementDigits - add one to a number held as a string of digits, of any
+//  length
+func incrementDigits(s string) string {
+	digits := []byte(s)
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return string(digits)
+		}
+		digits[i] = '0'
+	}
+	return "1" + string(digits)
+}
+
+// withLevels - a new version with the given levels and pre-release, and the
+//  options of v
+func (v Version) withLevels(levels []string, pre []string) *Version {
+	next := fromDigits(levels)
+	v.options()(next)
+	if len(pre) > 0 {
+		next.pre = pre
+		next.asString += "-" + strings.Join(pre, ".")
+	}
+	return next
+}
@@ -0,0 +1,139 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpLevel(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		ver    string
+		semver bool
+		index  int
+		want   string
+		err    error
+	}{
+		"major":                     {ver: "1.2.3", index: 0, want: "2.0.0"},
+		"minor":                     {ver: "1.2.3", index: 1, want: "1.3.0"},
+		"patch":                     {ver: "1.2.3", index: 2, want: "1.2.4"},
+		"four levels, second":       {ver: "1.2.3.4", index: 1, want: "1.3.0.0"},
+		"four levels, last":         {ver: "1.2.3.4", index: 3, want: "1.2.3.5"},
+		"six levels, fourth":        {ver: "10.9.8.7.6.5", index: 3, want: "10.9.8.8.0.0"},
+		"single level":              {ver: "7", index: 0, want: "8"},
+		"padded":                    {ver: "1.2", index: 3, want: "1.2.0.1"},
+		"long level":                {ver: "1.99999999999999999999.5", index: 1, want: "1.100000000000000000000.0"},
+		"negative index":            {ver: "1.2.3", index: -1, err: InvalidIndex},
+		"pre-release to release":    {ver: "1.2.0-rc.1", semver: true, index: 1, want: "1.2.0"},
+		"pre-release past release":  {ver: "1.2.3-rc.1", semver: true, index: 1, want: "1.3.0"},
+		"pre-release patch":         {ver: "1.2.3-rc.1", semver: true, index: 2, want: "1.2.3"},
+		"pre-release major":         {ver: "2.0.0-beta", semver: true, index: 0, want: "2.0.0"},
+		"pre-release padded":        {ver: "1.2.3-rc.1", semver: true, index: 3, want: "1.2.3.1"},
+		"pre-release padded twice":  {ver: "1.2.3-rc.1", semver: true, index: 4, want: "1.2.3.0.1"},
+		"build metadata is dropped": {ver: "1.2.3+build.5", semver: true, index: 2, want: "1.2.4"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ver, err := NewVersion(tc.ver)
+			if tc.semver {
+				ver, err = NewSemVer(tc.ver)
+			}
+			require.NoError(err)
+
+			next, err := ver.BumpLevel(tc.index)
+			require.ErrorIs(err, tc.err)
+			if tc.err != nil {
+				return
+			}
+			require.Equal(tc.want, next.String())
+			require.Equal(tc.ver, ver.String(), "the original is unchanged")
+			require.True(next.GreaterThan(ver), "the next version is later")
+
+			parsed, err := NewVersion(tc.want)
+			require.NoError(err)
+			require.True(next.Equal(parsed))
+		})
+	}
+}
+
+func TestNextLevels(t *testing.T) {
+	require := require.New(t)
+
+	ver, err := NewVersion("4.3.2.1")
+	require.NoError(err)
+	require.Equal("5.0.0.0", ver.NextMajor().String())
+	require.Equal("4.4.0.0", ver.NextMinor().String())
+	require.Equal("4.3.3.0", ver.NextPatch().String())
+
+	ver, err = NewVersion("4")
+	require.NoError(err)
+	require.Equal("5", ver.NextMajor().String())
+	require.Equal("4.1", ver.NextMinor().String())
+	require.Equal("4.0.1", ver.NextPatch().String())
+}
+
+func TestBumpKeepsPolicy(t *testing.T) {
+	require := require.New(t)
+
+	ver, err := NewVersion("1.2.3.4", WithSignificantLevels(2))
+	require.NoError(err)
+
+	next := ver.NextPatch()
+	require.Equal("1.2.4.0", next.String())
+	other, err := NewVersion("1.2.9")
+	require.NoError(err)
+	require.True(next.Equal(other), "only 2 levels are significant")
+}
+
+func TestNextPrerelease(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		ver  string
+		id   string
+		want string
+		err  error
+	}{
+		"release":             {ver: "1.2.3", id: "rc", want: "1.2.4-rc.0"},
+		"release, no id":      {ver: "1.2.3", want: "1.2.4-0"},
+		"release, dotted id":  {ver: "1.2.3", id: "alpha.x", want: "1.2.4-alpha.x.0"},
+		"same id":             {ver: "1.0.0-rc.1", id: "rc", want: "1.0.0-rc.2"},
+		"no id":               {ver: "1.0.0-rc.1", want: "1.0.0-rc.2"},
+		"last numeric":        {ver: "1.0.0-rc.1.beta", want: "1.0.0-rc.2.beta"},
+		"no numeric":          {ver: "1.0.0-rc", id: "rc", want: "1.0.0-rc.0"},
+		"carry":               {ver: "1.0.0-rc.99", want: "1.0.0-rc.100"},
+		"bigger than an int":  {ver: "1.0.0-rc.99999999999999999999", want: "1.0.0-rc.100000000000000000000"},
+		"different id":        {ver: "1.0.0-alpha.3", id: "beta", want: "1.0.0-beta.0"},
+		"current is a prefix": {ver: "1.0.0-r.3", id: "rc", want: "1.0.0-rc.0"},
+		"build metadata":      {ver: "1.0.0-rc.1+build.5", want: "1.0.0-rc.2"},
+		"bad id":              {ver: "1.2.3", id: "r_c", err: InvalidPrerelease},
+		"empty identifier":    {ver: "1.2.3", id: "rc..1", err: InvalidPrerelease},
+		"leading zero":        {ver: "1.2.3", id: "01", err: InvalidPrerelease},
+		"not three levels":    {ver: "1.2.3.4", id: "rc", err: InvalidSemVer},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ver, err := NewSemVer(tc.ver)
+			if err != nil {
+				ver, err = NewVersion(tc.ver)
+			}
+			require.NoError(err)
+
+			next, err := ver.NextPrerelease(tc.id)
+			require.ErrorIs(err, tc.err)
+			if tc.err != nil {
+				return
+			}
+			require.Equal(tc.want, next.String())
+			require.True(next.GreaterThan(ver))
+
+			parsed, err := NewSemVer(tc.want)
+			require.NoError(err)
+			require.True(next.Equal(parsed))
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	InvalidCalVerFormat = fmt.Errorf("Invalid CalVer format string")
+	InvalidCalVer       = fmt.Errorf("Invalid CalVer string")
+)
+
+// calverTokens - the levels a CalVer format can have, see calver.org
+//  - YYYY full year, 2006
+//  - YY short year, 6, 16 or 106, years since 2000
+//  - 0Y zero-padded short year, 06, 16 or 106
+//  - MM and 0M month, 1 or 01 to 12
+//  - WW and 0W ISO week, 1 or 01 to 53
+//  - DD and 0D day of the month, 1 or 01 to 31
+//  - MAJOR, MINOR and MICRO any number
+var calverTokens = map[string]bool{
+	"YYYY": true, "YY": true, "0Y": true,
+	"MM": true, "0M": true,
+	"WW": true, "0W": true,
+	"DD": true, "0D": true,
+	"MAJOR": true, "MINOR": true, "MICRO": true,
+}
+
+// CalVer - a calendar version, eg 2024.03.1 or 24.04, with the meaning of
+//  each level given by a format string, eg YYYY.0M.MICRO or YY.0M, see
+//  calverTokens. Its Version holds the levels as numbers, which are compared
+//  as any other Version, so CalVers should only be compared with others of
+//  the same format. The Version is not embedded, so that only the methods
+//  that keep the date valid are available on a CalVer
+type CalVer struct {
+	ver    *Version
+	format string
+	year   int
+	month  int // 0 if the format has no month
+	week   int // 0 if the format has no week
+	day    int // 0 if the format has no day
+}
+
+// NewCalVer - create a CalVer from a version string and the format it
+//  follows, with the same options as NewVersion. The format must have a
+//  year, and a day must follow a month, which can't be used with a week
+func NewCalVer(s, format string, opts ...Option) (*CalVer, error) {
+	tokens := strings.Split(format, ".")
+	if err := checkCalVerFormat(format, tokens); err != nil {
+		return nil, err
+	}
+	if s == "" {
+		return nil, InvalidVersion
+	}
+
+	levels := strings.Split(s, ".")
+	if len(levels) != len(tokens) {
+		return nil, fmt.Errorf("%w: %q does not match %s", InvalidCalVer, s, format)
+	}
+
+	c := &CalVer{format: format}
+	for i, token := range tokens {
+		val, err := parseCalVerLevel(token, levels[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q level %d does not match %s, %v", InvalidCalVer, s, i, token, err)
+		}
+		switch token {
+		case "YYYY":
+			c.year = val
+		case "YY", "0Y":
+			c.year = 2000 + val
+		case "MM", "0M":
+			c.month = val
+		case "WW", "0W":
+			c.week = val
+		case "DD", "0D":
+			c.day = val
+		}
+	}
+
+	// a date that doesn't exist normalizes to a different one
+	if c.day > 0 {
+		date := time.Date(c.year, time.Month(c.month), c.day, 0, 0, 0, 0, time.UTC)
+		if date.Month() != time.Month(c.month) {
+			return nil, fmt.Errorf("%w: %q, %d-%02d-%02d is not a date", InvalidCalVer, s, c.year, c.month, c.day)
+		}
+	}
+	if c.week > 0 {
+		if year, _ := c.Date().ISOWeek(); year != c.year {
+			return nil, fmt.Errorf("%w: %q, %d has no week %d", InvalidCalVer, s, c.year, c.week)
+		}
+	}
+
+	ver, err := NewVersion(s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.ver = ver
+	return c, nil
+}
+
+// checkCalVerFormat - check the format has known tokens, and enough of
+//  the date to make sense
+func checkCalVerFormat(format string, tokens []string) error {
+	seen := map[string]bool{}
+	for _, token := range tokens {
+		if !calverTokens[token] {
+			return fmt.Errorf("%w: %q has unknown level %q", InvalidCalVerFormat, format, token)
+		}
+		kind := strings.TrimLeft(token, "0")
+		switch kind {
+		case "YYYY", "Y":
+			kind = "YY"
+		case "M":
+			kind = "MM"
+		case "W":
+			kind = "WW"
+		case "D":
+			kind = "DD"
+		}
+		if seen[kind] {
+			return fmt.Errorf("%w: %q has more than one %s", InvalidCalVerFormat, format, kind)
+		}
+		seen[kind] = true
+	}
+
+	switch {
+	case !seen["YY"]:
+		return fmt.Errorf("%w: %q has no year", InvalidCalVerFormat, format)
+	case seen["DD"] && !seen["MM"]:
+		return fmt.Errorf("%w: %q has a day but no month", InvalidCalVerFormat, format)
+	case seen["WW"] && seen["MM"]:
+		return fmt.Errorf("%w: %q has both a week and a month", InvalidCalVerFormat, format)
+	}
+	return nil
+}
+
+// parseCalVerLevel - parse one level of a CalVer, checking it is padded as
+//  the token requires and in range
+func parseCalVerLevel(token, level string) (int, error) {
+	if !isNumeric(level) {
+		return 0, fmt.Errorf("not a number")
+	}
+
+	padded := strings.HasPrefix(token, "0")
+	switch {
+	case padded && len(level) < 2:
+		return 0, fmt.Errorf("not zero-padded")
+	case padded && len(level) > 2 && level[0] == '0':
+		return 0, fmt.Errorf("too much padding")
+	case !padded && len(level) > 1 && level[0] == '0':
+		return 0, fmt.Errorf("zero-padded")
+	}
+
+	// MAJOR, MINOR and MICRO can be any number, they are not part of the date
+	switch token {
+	case "MAJOR", "MINOR", "MICRO":
+		return 0, nil
+	}
+	val, err := strconv.Atoi(level)
+	if err != nil {
+		return 0, fmt.Errorf("too big")
+	}
+
+	switch token {
+	case "YYYY":
+		if val < 1 {
+			return 0, fmt.Errorf("out of range")
+		}
+	case "MM", "0M":
+		if val < 1 || val > 12 {
+			return 0, fmt.Errorf("out of range")
+		}
+	case "WW", "0W":
+		if val < 1 || val > 53 {
+			return 0, fmt.Errorf("out of range")
+		}
+	case "DD", "0D":
+		if val < 1 || val > 31 {
+			return 0, fmt.Errorf("out of range")
+		}
+	}
+	return val, nil
+}
+
+// Version - return the levels of the version as a Version, for use with the
+//  rest of the package, such as Constraint.Check. It is a copy, so changing
+//  it does not change the CalVer
+func (c CalVer) Version() *Version {
+	ver := *c.ver
+	return &ver
+}
+
+// String - print the version as a string
+func (c CalVer) String() string {
+	return c.ver.String()
+}
+
+// Len - return the number of levels in the version
+func (c CalVer) Len() int {
+	return c.ver.Len()
+}
+
+// Part - return the level of the version for the given index, see Version.Part
+func (c CalVer) Part(index int) (int, error) {
+	return c.ver.Part(index)
+}
+
+// Format - return the format the version follows
+func (c CalVer) Format() string {
+	return c.format
+}
+
+// Date - return the date of the version. Missing parts of the date are its
+//  start, so 24.04 is 2024-04-01, and a week is its Monday
+func (c CalVer) Date() time.Time {
+	if c.week > 0 {
+		// ISO week 1 is the week with the 4th of January in it
+		jan4 := time.Date(c.year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		return monday.AddDate(0, 0, 7*(c.week-1))
+	}
+
+	month, day := c.month, c.day
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(c.year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// LessThan - check if version is less than another version
+func (c CalVer) LessThan(c2 *CalVer) bool {
+	return c.ver.LessThan(c2.ver)
+}
+
+// GreaterThan - check if version is greater than another version
+func (c CalVer) GreaterThan(c2 *CalVer) bool {
+	return c.ver.GreaterThan(c2.ver)
+}
+
+// Equal - check if version is equal to another version
+func (c CalVer) Equal(c2 *CalVer) bool {
+	return c.ver.Equal(c2.ver)
+}
+
+// Compare - compare two versions, return 0 if they are equal, -1 if c < c2, or 1 if c > c2
+//  Compares the levels as numbers, see Version.Compare
+func (c CalVer) Compare(c2 *CalVer) int {
+	return c.ver.Compare(c2.ver)
+}
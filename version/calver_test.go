@@ -0,0 +1,154 @@
+package version
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalVer(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		ver    string
+		format string
+		date   string
+	}{
+		"full year, padded month, micro": {
+			ver:    "2024.03.1",
+			format: "YYYY.0M.MICRO",
+			date:   "2024-03-01",
+		},
+		"ubuntu": {
+			ver:    "24.04",
+			format: "YY.0M",
+			date:   "2024-04-01",
+		},
+		"short year and month": {
+			ver:    "6.4",
+			format: "YY.MM",
+			date:   "2006-04-01",
+		},
+		"padded short year": {
+			ver:    "06.11",
+			format: "0Y.MM",
+			date:   "2006-11-01",
+		},
+		"long short year": {
+			ver:    "106.1",
+			format: "YY.MM",
+			date:   "2106-01-01",
+		},
+		"day": {
+			ver:    "2024.2.29",
+			format: "YYYY.MM.DD",
+			date:   "2024-02-29",
+		},
+		"padded day": {
+			ver:    "2023.12.05.3",
+			format: "YYYY.0M.0D.MICRO",
+			date:   "2023-12-05",
+		},
+		"week": {
+			ver:    "2021.01",
+			format: "YYYY.0W",
+			date:   "2021-01-04",
+		},
+		"week 53": {
+			ver:    "2020.53",
+			format: "YYYY.WW",
+			date:   "2020-12-28",
+		},
+		"major and minor": {
+			ver:    "3.2024.1",
+			format: "MAJOR.YYYY.MINOR",
+			date:   "2024-01-01",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			c, err := NewCalVer(tc.ver, tc.format)
+			require.NoError(err, "should create the version")
+			require.Equal(tc.ver, c.String(), "should have the expected string")
+			require.Equal(tc.format, c.Format(), "should have the format")
+			require.Equal(tc.date, c.Date().Format("2006-01-02"), "should have the date")
+		})
+	}
+
+	for _, tc := range []struct {
+		ver    string
+		format string
+		err    error
+	}{
+		{"2024.03", "YYYY.0M.MICRO", InvalidCalVer},
+		{"2024.3.1", "YYYY.0M.MICRO", InvalidCalVer},
+		{"24.13", "YY.MM", InvalidCalVer},
+		{"24.0", "YY.MM", InvalidCalVer},
+		{"24.04", "YY.MM", InvalidCalVer},
+		{"024.4", "0Y.MM", InvalidCalVer},
+		{"2023.2.29", "YYYY.MM.DD", InvalidCalVer},
+		{"2024.4.31", "YYYY.MM.DD", InvalidCalVer},
+		{"2024.4.32", "YYYY.MM.DD", InvalidCalVer},
+		{"2021.53", "YYYY.WW", InvalidCalVer},
+		{"0.1", "YYYY.MICRO", InvalidCalVer},
+		{"2024.a", "YYYY.MICRO", InvalidCalVer},
+		{"2024.+1", "YYYY.MICRO", InvalidCalVer},
+		{"", "YYYY", InvalidVersion},
+		{"1.2", "MAJOR.MINOR", InvalidCalVerFormat},
+		{"2024.1", "YYYY.Q", InvalidCalVerFormat},
+		{"2024.24", "YYYY.YY", InvalidCalVerFormat},
+		{"2024.1", "YYYY.DD", InvalidCalVerFormat},
+		{"2024.1.1", "YYYY.MM.WW", InvalidCalVerFormat},
+		{"2024.1", "YYYY..MM", InvalidCalVerFormat},
+	} {
+		c, err := NewCalVer(tc.ver, tc.format)
+		require.Nil(c, "%q in %s should not return a version", tc.ver, tc.format)
+		require.ErrorIs(err, tc.err, "%q in %s should return the expected error", tc.ver, tc.format)
+	}
+}
+
+func TestCalVerCompare(t *testing.T) {
+	require := require.New(t)
+
+	ordered := []string{"2023.12.5", "2024.01.0", "2024.01.2", "2024.01.10", "2024.02.0", "2025.01.0"}
+	for i := 1; i < len(ordered); i++ {
+		c1, _ := NewCalVer(ordered[i-1], "YYYY.0M.MICRO")
+		c2, _ := NewCalVer(ordered[i], "YYYY.0M.MICRO")
+		require.Equal(-1, c1.Compare(c2), "%s should be before %s", c1, c2)
+		require.True(c1.LessThan(c2), "%s should be before %s", c1, c2)
+		require.True(c2.GreaterThan(c1), "%s should be after %s", c2, c1)
+		require.True(c1.Equal(c1), "%s should equal itself", c1)
+	}
+
+	// its Version works with the rest of the package
+	c, _ := NewCalVer("24.04", "YY.0M")
+	con, _ := NewConstraint(">=24.4, <24.10")
+	require.True(con.Check(c.Version()), "should check a constraint")
+	require.Equal(2, c.Len(), "should have the number of levels")
+	month, err := c.Part(1)
+	require.NoError(err, "should get a level")
+	require.Equal(4, month, "should get the month level")
+
+	padded, _ := NewCalVer("24.04", "YY.0M", WithPolicy(ZeroPadded))
+	point, _ := NewCalVer("24.04.0", "YY.0M.MICRO", WithPolicy(ZeroPadded))
+	require.True(padded.Equal(point), "should take the Version options")
+}
+
+func TestCalVerDecode(t *testing.T) {
+	require := require.New(t)
+
+	// only NewCalVer, which checks the date, can create a CalVer, so decoding
+	//  into one fails rather than skipping the checks
+	var zero CalVer
+	require.NotPanics(func() {
+		require.Error(json.Unmarshal([]byte(`"2024.03.1"`), &zero), "should not decode into a zero CalVer")
+	})
+
+	c, _ := NewCalVer("2024.03.1", "YYYY.0M.MICRO")
+	require.Error(json.Unmarshal([]byte(`"2024.13.1"`), c), "should not decode an invalid date")
+	require.Equal("2024.03.1", c.String(), "should keep the version")
+	require.Equal("YYYY.0M.MICRO", c.Format(), "should keep the format")
+	require.Equal("2024-03-01", c.Date().Format("2006-01-02"), "should keep the date")
+}
@@ -0,0 +1,122 @@
+package version
+
+import (
+	"sort"
+)
+
+// Comparable - a version type that can be ordered against others of its type
+type Comparable[T any] interface {
+	Compare(T) int
+}
+
+// CompareFunc - compare two versions with their Compare method, return 0 if
+//  they are equal, -1 if a < b, or 1 if a > b. Has the signature expected by
+//  slices.SortFunc, slices.BinarySearchFunc and friends, eg
+//    slices.SortFunc(versions, version.CompareFunc[*version.Version])
+func CompareFunc[T Comparable[T]](a, b T) int {
+	return a.Compare(b)
+}
+
+// Collection - a list of versions, ordered by Compare when sorted
+//  eg sort.Sort(Collection(versions))
+type Collection []*Version
+
+// Len - the number of versions in the collection
+func (c Collection) Len() int {
+	return len(c)
+}
+
+// Less - check if version i is less than version j
+func (c Collection) Less(i, j int) bool {
+	return c[i].LessThan(c[j])
+}
+
+// Swap - swap versions i and j
+func (c Collection) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+// SortWith - sort the collection in place using the Comparator, keeping
+//  equal versions in their original order. Unlike sort.Sort, which uses
+//  Compare and so the policy of each version, this gives a consistent order
+//  to versions with different policies
+func (c Collection) SortWith(cmp *Comparator) {
+	sort.SliceStable(c, func(i, j int) bool { return cmp.Compare(c[i], c[j]) < 0 })
+}
+
+// Latest - return the greatest version in the collection, the first of them
+//  if several are equal, or nil if the collection is empty
+func (c Collection) Latest() *Version {
+	var latest *Version
+	for _, v := range c {
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// Oldest - return the lowest version in the collection, the first of them
+//  if several are equal, or nil if the collection is empty
+func (c Collection) Oldest() *Version {
+	var oldest *Version
+	for _, v := range c {
+		if oldest == nil || v.LessThan(oldest) {
+			oldest = v
+		}
+	}
+	return oldest
+}
+
+// Filter - return a new collection of the versions for which keep returns
+//  true, in their original order
+func (c Collection) Filter(keep func(*Version) bool) Collection {
+	filtered := Collection{}
+	for _, v := range c {
+		if keep(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// Dedup - return a new collection without the versions that are equal to
+//  an earlier one, in their original order. Versions that differ only in
+//  build metadata are equal, so only the first is kept, while 1.1.1 and
+//  1.1.1.0 are different versions and both are kept
+func (c Collection) Dedup() Collection {
+	// sort the positions, so equal versions are next to each other with the
+	//  first of them leading
+	order := make([]int, len(c))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return c[order[i]].LessThan(c[order[j]]) })
+
+	dup := make([]bool, len(c))
+	for i := 1; i < len(order); i++ {
+		if c[order[i]].Equal(c[order[i-1]]) {
+			dup[order[i]] = true
+		}
+	}
+
+	deduped := Collection{}
+	for i, v := range c {
+		if !dup[i] {
+			deduped = append(deduped, v)
+		}
+	}
+	return deduped
+}
+
+// GroupByMajor - split the collection by the first level of each version,
+//  keyed by its digits without leading zeros, so a level of any length has
+//  its own group, each group keeping the versions in their original order
+func (c Collection) GroupByMajor() map[string]Collection {
+	groups := map[string]Collection{}
+	for _, v := range c {
+		major := v.asArray[0]
+		groups[major] = append(groups[major], v)
+	}
+	return groups
+}
@@ -0,0 +1,92 @@
+package version
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// collectionOf - create a Collection from version strings, as SemVer if
+//  they have a pre-release or build metadata
+func collectionOf(require *require.Assertions, strs ...string) Collection {
+	c := Collection{}
+	for _, s := range strs {
+		v, err := parseConstraintVersion(s)
+		require.NoError(err, "should create the version %q", s)
+		c = append(c, v)
+	}
+	return c
+}
+
+// stringsOf - the strings of the versions in a Collection
+func stringsOf(c Collection) []string {
+	strs := []string{}
+	for _, v := range c {
+		strs = append(strs, v.String())
+	}
+	return strs
+}
+
+func TestCollectionSort(t *testing.T) {
+	require := require.New(t)
+
+	c := collectionOf(require, "1.10", "1.1.1.0", "1.2", "1.1.1", "0.9", "1.0.0-rc.1", "1.0.0", "1.1")
+	want := []string{"0.9", "1.0.0-rc.1", "1.0.0", "1.1", "1.1.1", "1.1.1.0", "1.2", "1.10"}
+
+	sort.Sort(c)
+	require.Equal(want, stringsOf(c), "should sort by Compare")
+
+	c = collectionOf(require, "1.10", "1.1.1.0", "1.2", "1.1.1", "0.9", "1.0.0-rc.1", "1.0.0", "1.1")
+	sort.Slice(c, func(i, j int) bool { return CompareFunc(c[i], c[j]) < 0 })
+	require.Equal(want, stringsOf(c), "CompareFunc should sort by Compare")
+}
+
+func TestCollectionLatestOldest(t *testing.T) {
+	require := require.New(t)
+
+	c := collectionOf(require, "1.1.1", "2.0.0-rc.1", "1.1.1.0", "0.9.9", "1.9")
+	require.Equal("2.0.0-rc.1", c.Latest().String(), "should get the latest")
+	require.Equal("0.9.9", c.Oldest().String(), "should get the oldest")
+
+	c = collectionOf(require, "1.0.0+a", "1.0.0+b", "0.1")
+	require.Equal("1.0.0+a", c.Latest().String(), "should get the first of equal versions")
+	c = collectionOf(require, "1.1.1.0", "1.1.1")
+	require.Equal("1.1.1.0", c.Latest().String(), "the longer version is the latest")
+	require.Equal("1.1.1", c.Oldest().String(), "the shorter version is the oldest")
+
+	require.Nil(Collection{}.Latest(), "an empty collection has no latest")
+	require.Nil(Collection{}.Oldest(), "an empty collection has no oldest")
+}
+
+func TestCollectionFilter(t *testing.T) {
+	require := require.New(t)
+
+	c := collectionOf(require, "1.3", "2.1", "1.5.2", "1.4", "0.2")
+	constraint, _ := NewConstraint(">=1.4, <2")
+	require.Equal([]string{"1.5.2", "1.4"}, stringsOf(c.Filter(constraint.Check)), "should keep matching versions in order")
+	require.Len(c, 5, "should leave the collection alone")
+	require.Empty(c.Filter(func(*Version) bool { return false }), "should filter out everything")
+}
+
+func TestCollectionDedup(t *testing.T) {
+	require := require.New(t)
+
+	c := collectionOf(require, "1.1.1", "1.2", "1.1.1.0", "1.0.0+b", "1.1.1", "1.2", "1.0.0+a", "1.0.0-rc.1")
+	require.Equal([]string{"1.1.1", "1.2", "1.1.1.0", "1.0.0+b", "1.0.0-rc.1"}, stringsOf(c.Dedup()), "should keep the first of equal versions, in order")
+	require.Empty(Collection{}.Dedup(), "should dedup an empty collection")
+}
+
+func TestCollectionGroupByMajor(t *testing.T) {
+	require := require.New(t)
+
+	c := collectionOf(require, "1.3", "2.1", "1.5.2", "10", "2.0.0-rc.1", "01.7",
+		"99999999999999999999.1.0+a", "88888888888888888888.2.0+b", "99999999999999999999.3.0+c")
+	groups := c.GroupByMajor()
+	require.Len(groups, 5, "should have a group per major level")
+	require.Equal([]string{"1.3", "1.5.2", "01.7"}, stringsOf(groups["1"]), "should group major 1")
+	require.Equal([]string{"2.1", "2.0.0-rc.1"}, stringsOf(groups["2"]), "should group major 2")
+	require.Equal([]string{"10"}, stringsOf(groups["10"]), "should group major 10")
+	require.Equal([]string{"99999999999999999999.1.0+a", "99999999999999999999.3.0+c"}, stringsOf(groups["99999999999999999999"]), "should group a major too large for an int")
+	require.Equal([]string{"88888888888888888888.2.0+b"}, stringsOf(groups["88888888888888888888"]), "should not merge majors too large for an int")
+}
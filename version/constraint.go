@@ -0,0 +1,260 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	InvalidConstraint = fmt.Errorf("Invalid Constraint string")
+	ConstraintNotMet  = fmt.Errorf("Version does not satisfy constraint")
+)
+
+// Constraint - a set of rules a version must satisfy, eg ">=1.4, <2.0"
+//  - rules separated by ',' or spaces must all be satisfied
+//  - groups of rules separated by '||' are alternatives, any one will do
+//    A rule is an operator, one of = == != > >= < <= ^ ~, followed by a version.
+//    No operator is the same as =
+//  - ^1.2.3 allows changes that keep the first non-zero level, >=1.2.3, <2
+//    and ^0.2.3 is >=0.2.3, <0.3
+//  - ~1.2.3 allows changes below the second level, >=1.2.3, <1.3
+//    and ~1 is >=1, <2
+//  - trailing levels of x, X or * match anything, 1.2.x is >=1.2, <1.3
+//    and * matches every version
+//    Versions are compared with Version.Compare, so 1.2 < 1.2.0 and an upper
+//    bound such as <2 excludes 2.0.0 and all its pre-releases
+type Constraint struct {
+	asString string
+	groups   [][]rule
+}
+
+// rule - a single comparison against a version
+type rule struct {
+	origin string // the rule as written, if it expanded to this one, eg ^1.2
+	op     string
+	ver    *Version
+}
+
+// NewConstraint - create a Constraint from a constraint string
+func NewConstraint(s string) (*Constraint, error) {
+	c := &Constraint{asString: s}
+
+	for _, group := range strings.Split(s, "||") {
+		terms := strings.Fields(strings.ReplaceAll(group, ",", " "))
+		if len(terms) == 0 {
+			return nil, fmt.Errorf("%w: %q has an empty group", InvalidConstraint, s)
+		}
+
+		rules := []rule{}
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+			// allow a space between the operator and the version, ">= 1.4"
+			if strings.Trim(term, "=!<>^~") == "" && i+1 < len(terms) {
+				i++
+				term += terms[i]
+			}
+			parsed, err := parseRule(term)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, parsed...)
+		}
+		c.groups = append(c.groups, rules)
+	}
+
+	return c, nil
+}
+
+// parseRule - parse one rule, expanding ^, ~ and wildcards into the plain
+//  comparisons they stand for
+func parseRule(term string) ([]rule, error) {
+	op, verStr := splitOperator(term)
+	if verStr == "" {
+		return nil, fmt.Errorf("%w: %q is missing a version", InvalidConstraint, term)
+	}
+
+	levels, wild, err := parseLevels(verStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", InvalidConstraint, term, err)
+	}
+	cmp := func(op string, ver *Version) rule {
+		return rule{origin: term, op: op, ver: ver}
+	}
+
+	if wild {
+		if len(levels) == 0 {
+			switch op {
+			case "=", ">=", "<=", "^", "~":
+				return []rule{}, nil
+			}
+			return nil, fmt.Errorf("%w: %q cannot be satisfied", InvalidConstraint, term)
+		}
+		prefix := fromDigits(levels)
+		switch op {
+		case "=":
+			return []rule{cmp(">=", prefix), cmp("<", bumpLevel(levels, len(levels)-1))}, nil
+		case ">=", "<":
+			return []rule{cmp(op, prefix)}, nil
+		case ">":
+			return []rule{cmp(">=", bumpLevel(levels, len(levels)-1))}, nil
+		case "<=":
+			return []rule{cmp("<", bumpLevel(levels, len(levels)-1))}, nil
+		case "!=":
+			return nil, fmt.Errorf("%w: %q cannot exclude a wildcard", InvalidConstraint, term)
+		}
+		// ^1.x and ~1.2.x are the same as ^1 and ~1.2
+		verStr = prefix.String()
+	}
+
+	ver, err := parseConstraintVersion(verStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", InvalidConstraint, term, err)
+	}
+
+	switch op {
+	case "^":
+		// bump the first non-zero level, or the last if they are all zero
+		i := 0
+		for i < len(levels)-1 && levels[i] == "0" {
+			i++
+		}
+		return []rule{cmp(">=", ver), cmp("<", bumpLevel(levels, i))}, nil
+	case "~":
+		i := 1
+		if len(levels) == 1 {
+			i = 0
+		}
+		return []rule{cmp(">=", ver), cmp("<", bumpLevel(levels, i))}, nil
+	}
+	return []rule{{op: op, ver: ver}}, nil
+}
+
+// splitOperator - split the operator from the front of a rule, "==" is
+//  returned as "=", as is no operator
+func splitOperator(term string) (string, string) {
+	for _, op := range []string{">=", "<=", "!=", "==", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, op) {
+			if op == "==" {
+				return "=", term[2:]
+			}
+			return op, term[len(op):]
+		}
+	}
+	return "=", term
+}
+
+// parseLevels - the numeric levels of a constraint version, up to the first
+//  wildcard level, and whether there was one. Every level after a wildcard
+//  must also be a wildcard
+func parseLevels(s string) ([]string, bool, error) {
+	core := s
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core = s[:i]
+	}
+
+	levels := []string{}
+	strArray := strings.Split(core, ".")
+	for i, str := range strArray {
+		if str == "x" || str == "X" || str == "*" {
+			for _, rest := range strArray[i:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return nil, false, InvalidElement
+				}
+			}
+			if core != s {
+				return nil, false, InvalidPrerelease
+			}
+			return levels, true, nil
+		}
+		ver, err := NewVersion(str)
+		if err != nil {
+			return nil, false, err
+		}
+		levels = append(levels, ver.asArray[0])
+	}
+	return levels, false, nil
+}
+
+// parseConstraintVersion - parse the version of a rule, as SemVer if it has
+//  a pre-release or build metadata
+func parseConstraintVersion(s string, opts ...Option) (*Version, error) {
+	if strings.ContainsAny(s, "-+") {
+		return NewSemVer(s, opts...)
+	}
+	return NewVersion(s, opts...)
+}
+
+// bumpLevel - the lowest version with a level i above the given levels,
+//  levels[:i] with level i incremented
+func bumpLevel(levels []string, i int) *Version {
+	bumped := append([]string{}, levels[:i+1]...)
+	bumped[i] = incrementDigits(bumped[i])
+	return fromDigits(bumped)
+}
+
+// String - print the constraint as a string
+func (c Constraint) String() string {
+	return c.asString
+}
+
+// Check - check if the version satisfies the constraint
+func (c Constraint) Check(v *Version) bool {
+	return c.Validate(v) == nil
+}
+
+// Validate - check if the version satisfies the constraint. If it doesn't
+//  the error explains which rule of each group it failed
+func (c Constraint) Validate(v *Version) error {
+	reasons := []string{}
+	for _, group := range c.groups {
+		failed := ""
+		for _, r := range group {
+			if !r.check(v) {
+				failed = r.explain(v)
+				break
+			}
+		}
+		if failed == "" {
+			return nil
+		}
+		reasons = append(reasons, failed)
+	}
+	return fmt.Errorf("%w %q: %s", ConstraintNotMet, c.asString, strings.Join(reasons, ", or "))
+}
+
+// check - check if the version satisfies the rule
+func (r rule) check(v *Version) bool {
+	cmp := v.Compare(r.ver)
+	switch r.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// explain - describe why the version does not satisfy the rule
+func (r rule) explain(v *Version) string {
+	reasons := map[string]string{
+		"=":  "is not equal to",
+		"!=": "is equal to",
+		">":  "is not greater than",
+		">=": "is less than",
+		"<":  "is not less than",
+		"<=": "is greater than",
+	}
+	msg := fmt.Sprintf("%s %s %s", v, reasons[r.op], r.ver)
+	if r.origin != "" {
+		msg += fmt.Sprintf(" (from %s)", r.origin)
+	}
+	return msg
+}
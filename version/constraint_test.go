@@ -0,0 +1,172 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraint(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		constraint string
+		pass       []string
+		fail       []string
+	}{
+		"range": {
+			constraint: ">=1.4, <2.0",
+			pass:       []string{"1.4", "1.4.0", "1.9.99", "2"},
+			fail:       []string{"1.3.9", "2.0", "2.0.0", "3"},
+		},
+		"range with spaces": {
+			constraint: ">= 1.4 < 2",
+			pass:       []string{"1.4", "1.99"},
+			fail:       []string{"1.3", "2.0"},
+		},
+		"caret": {
+			constraint: "^1.2.3",
+			pass:       []string{"1.2.3", "1.2.4", "1.9.0", "1.99.99.99"},
+			fail:       []string{"1.2.2", "1.2", "2.0.0", "2"},
+		},
+		"caret, zero major": {
+			constraint: "^0.2.3",
+			pass:       []string{"0.2.3", "0.2.9"},
+			fail:       []string{"0.2.2", "0.3.0", "1.0.0"},
+		},
+		"caret, zero minor": {
+			constraint: "^0.0.3",
+			pass:       []string{"0.0.3", "0.0.3.1"},
+			fail:       []string{"0.0.4", "0.1.0"},
+		},
+		"tilde": {
+			constraint: "~1.2",
+			pass:       []string{"1.2", "1.2.0", "1.2.99"},
+			fail:       []string{"1.1.9", "1.3", "1.3.0"},
+		},
+		"tilde, one level": {
+			constraint: "~1",
+			pass:       []string{"1", "1.9.9"},
+			fail:       []string{"0.9", "2.0.0"},
+		},
+		"wildcard": {
+			constraint: "1.2.x",
+			pass:       []string{"1.2", "1.2.0", "1.2.7.1"},
+			fail:       []string{"1.1.9", "1.3.0"},
+		},
+		"wildcard, greater than": {
+			constraint: ">1.2.*",
+			pass:       []string{"1.3", "2.0.0"},
+			fail:       []string{"1.2.9", "1.2"},
+		},
+		"wildcard, less than or equal": {
+			constraint: "<=1.X",
+			pass:       []string{"1.9.9", "0.1"},
+			fail:       []string{"2", "2.0.0"},
+		},
+		"any": {
+			constraint: "*",
+			pass:       []string{"0", "1.2.3", "99.0"},
+		},
+		"or": {
+			constraint: "!=1.3.0 || >=2",
+			pass:       []string{"1.3", "1.3.1", "2.0.0"},
+			fail:       []string{"1.3.0"},
+		},
+		"or, alternative ranges": {
+			constraint: "^1.2 || ^3.1",
+			pass:       []string{"1.2.0", "1.9", "3.1.1"},
+			fail:       []string{"1.1", "2.0.0", "3.0", "4"},
+		},
+		"exact": {
+			constraint: "==1.2.3",
+			pass:       []string{"1.2.3"},
+			fail:       []string{"1.2.3.0", "1.2"},
+		},
+		"pre-release": {
+			constraint: ">=1.0.0-rc.1, <1.0.0",
+			pass:       []string{"1.0.0-rc.1", "1.0.0-rc.2"},
+			fail:       []string{"1.0.0-beta.9", "1.0.0"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+			require.NoError(err, "should create the constraint")
+			require.Equal(tc.constraint, c.String(), "Constraint should have the expected string")
+
+			for _, s := range tc.pass {
+				v, err := parseConstraintVersion(s)
+				require.NoError(err, "should create the version")
+				require.True(c.Check(v), "%s should satisfy %s", s, tc.constraint)
+				require.NoError(c.Validate(v), "%s should satisfy %s", s, tc.constraint)
+			}
+			for _, s := range tc.fail {
+				v, err := parseConstraintVersion(s)
+				require.NoError(err, "should create the version")
+				require.False(c.Check(v), "%s should not satisfy %s", s, tc.constraint)
+				require.ErrorIs(c.Validate(v), ConstraintNotMet, "%s should not satisfy %s", s, tc.constraint)
+			}
+		})
+	}
+}
+
+func TestConstraintExplain(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		constraint string
+		ver        string
+		msg        string
+	}{
+		"range": {
+			constraint: ">=1.4, <2.0",
+			ver:        "1.3.5",
+			msg:        `Version does not satisfy constraint ">=1.4, <2.0": 1.3.5 is less than 1.4`,
+		},
+		"caret": {
+			constraint: "^1.2.3",
+			ver:        "2.0.0",
+			msg:        `Version does not satisfy constraint "^1.2.3": 2.0.0 is not less than 2 (from ^1.2.3)`,
+		},
+		"or": {
+			constraint: "!=1.3.0 || >=2",
+			ver:        "1.3.0",
+			msg:        `Version does not satisfy constraint "!=1.3.0 || >=2": 1.3.0 is equal to 1.3.0, or 1.3.0 is less than 2`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			c, _ := NewConstraint(tc.constraint)
+			require.NotNil(c, "should create the constraint")
+			v, _ := NewVersion(tc.ver)
+			require.NotNil(v, "should create the version")
+
+			require.EqualError(c.Validate(v), tc.msg, "should explain the failure")
+		})
+	}
+}
+
+func TestConstraintErrors(t *testing.T) {
+	require := require.New(t)
+
+	for _, s := range []string{
+		"",
+		"  ",
+		">=1.4 ||",
+		">=",
+		">=1..4",
+		"^1.a",
+		"1.x.3",
+		"!=1.x",
+		">*",
+		"1.x-rc.1",
+		"1.2.3-01",
+	} {
+		c, err := NewConstraint(s)
+		require.Nil(c, "%q should not return a constraint", s)
+		require.ErrorIs(err, InvalidConstraint, "%q should return the expected error", s)
+	}
+}
@@ -0,0 +1,219 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var InvalidDebian = fmt.Errorf("Invalid Debian version string")
+
+// Debian - a Debian package version, [epoch:]upstream[-revision], compared
+//  as dpkg does, eg 1:2.30-0ubuntu1~20.04
+//  - epoch is a number, 0 if missing, and is compared first
+//  - upstream starts with a digit, and can contain [0-9A-Za-z.+~-:]
+//  - revision follows the last '-', and can contain [0-9A-Za-z.+~]
+//  A '~' sorts before anything, even the end of the version, so
+//  1.0~rc1 < 1.0 and 1.0~~ < 1.0~~a < 1.0~ < 1.0 < 1.0a
+type Debian struct {
+	asString string
+	epoch    int
+	upstream string
+	revision string
+}
+
+// NewDebian - create a Debian version from a version string, validated as
+//  dpkg does
+func NewDebian(s string) (*Debian, error) {
+	if s == "" {
+		return nil, InvalidVersion
+	}
+	if strings.IndexFunc(s, isSpace) >= 0 {
+		return nil, fmt.Errorf("%w: %q has embedded spaces", InvalidDebian, s)
+	}
+
+	d := &Debian{asString: s}
+	rest := s
+	if epoch, after, found := strings.Cut(s, ":"); found {
+		if epoch == "" {
+			return nil, fmt.Errorf("%w: %q has an empty epoch", InvalidDebian, s)
+		}
+		if !isNumeric(epoch) {
+			return nil, fmt.Errorf("%w: %q has a non-numeric epoch", InvalidDebian, s)
+		}
+		val, err := strconv.Atoi(epoch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q has an epoch that is too big", InvalidDebian, s)
+		}
+		d.epoch = val
+		rest = after
+	}
+
+	d.upstream = rest
+	if i := strings.LastIndex(rest, "-"); i >= 0 {
+		d.upstream, d.revision = rest[:i], rest[i+1:]
+		if d.revision == "" {
+			return nil, fmt.Errorf("%w: %q has an empty revision", InvalidDebian, s)
+		}
+	}
+
+	if d.upstream == "" {
+		return nil, fmt.Errorf("%w: %q has an empty upstream version", InvalidDebian, s)
+	}
+	if !isDigit(d.upstream[0]) {
+		return nil, fmt.Errorf("%w: %q upstream version does not start with a digit", InvalidDebian, s)
+	}
+	for _, c := range []byte(d.upstream) {
+		if !isAlnum(c) && strings.IndexByte(".-+~:", c) < 0 {
+			return nil, fmt.Errorf("%w: %q has an invalid character %q in the upstream version", InvalidDebian, s, c)
+		}
+	}
+	for _, c := range []byte(d.revision) {
+		if !isAlnum(c) && strings.IndexByte(".+~", c) < 0 {
+			return nil, fmt.Errorf("%w: %q has an invalid character %q in the revision", InvalidDebian, s, c)
+		}
+	}
+
+	return d, nil
+}
+
+// String - print the version as a string
+func (d Debian) String() string {
+	return d.asString
+}
+
+// Epoch - return the epoch of the version, 0 if it has none
+func (d Debian) Epoch() int {
+	return d.epoch
+}
+
+// Upstream - return the upstream part of the version
+func (d Debian) Upstream() string {
+	return d.upstream
+}
+
+// Revision - return the Debian revision of the version, "" if it has none
+func (d Debian) Revision() string {
+	return d.revision
+}
+
+// LessThan - check if version is less than another version
+func (d Debian) LessThan(d2 *Debian) bool {
+	return d.Compare(d2) == -1
+}
+
+// GreaterThan - check if version is greater than another version
+func (d Debian) GreaterThan(d2 *Debian) bool {
+	return d.Compare(d2) == 1
+}
+
+// Equal - check if version is equal to another version
+func (d Debian) Equal(d2 *Debian) bool {
+	return d.Compare(d2) == 0
+}
+
+// Compare - compare two versions, return 0 if they are equal, -1 if d < d2, or 1 if d > d2
+//  Compares the epochs, then the upstream versions, then the revisions,
+//  see compareDpkg. A missing revision is the same as 0, ie 1.0 == 1.0-0
+func (d Debian) Compare(d2 *Debian) int {
+	if d.epoch != d2.epoch {
+		if d.epoch < d2.epoch {
+			return -1
+		}
+		return 1
+	}
+	if c := compareDpkg(d.upstream, d2.upstream); c != 0 {
+		return c
+	}
+	return compareDpkg(d.revision, d2.revision)
+}
+
+// dpkgOrder - the weight of a character in a non-digit run, '~' before the
+//  end of the string, before letters, before everything else
+func dpkgOrder(s string, i int) int {
+	switch {
+	case i >= len(s):
+		return 0
+	case s[i] == '~':
+		return -1
+	case isDigit(s[i]):
+		return 0
+	case isAlpha(s[i]):
+		return int(s[i])
+	}
+	return int(s[i]) + 256
+}
+
+// compareDpkg - compare two upstream versions or revisions as dpkg's
+//  verrevcmp, return 0 if they are equal, -1 if a < b, or 1 if a > b.
+//  Alternately compares runs of non-digits, by dpkgOrder, and runs of
+//  digits, as numbers
+func compareDpkg(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			ac, bc := dpkgOrder(a, i), dpkgOrder(b, j)
+			if ac != bc {
+				return sign(ac - bc)
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+	return 0
+}
+
+// sign - -1, 0 or 1 as n is negative, zero or positive
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// isDigit - check the byte is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isAlpha - check the byte is an ASCII letter
+func isAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isAlnum - check the byte is an ASCII letter or digit
+func isAlnum(c byte) bool {
+	return isDigit(c) || isAlpha(c)
+}
+
+// isSpace - check the rune is white space or a control character
+func isSpace(r rune) bool {
+	return r <= ' ' || r == 0x7f
+}
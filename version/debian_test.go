@@ -0,0 +1,105 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebianCompare(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		v1   string
+		v2   string
+		rslt int
+	}{
+		// epochs
+		{"0:0-0", "0:0-0", 0},
+		{"0:1.0", "1.0", 0},
+		{"1:0-0", "2:0-0", -1},
+		{"1:1.0", "2.0", 1},
+		{"2:0.1", "10:0.1", -1},
+		// upstream
+		{"0:1.0-0", "0:2.0-0", -1},
+		{"1.0", "1.00", 0},
+		{"1.0", "1.0.0", -1},
+		{"1.2", "1.10", -1},
+		{"1.0a", "1.0", 1},
+		{"1.0a", "1.0+", -1},
+		{"1.0+dfsg-1", "1.0-1", 1},
+		{"1.0.dfsg", "1.0+dfsg", 1},
+		{"2.30", "2.30.1", -1},
+		{"1:2.30-0ubuntu1~20.04", "1:2.30-0ubuntu1", -1},
+		{"1:2.30-0ubuntu1~20.04", "1:2.30-0ubuntu1~18.04", 1},
+		// revisions
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-1", "1.0-1.1", -1},
+		{"1.0-1ubuntu1", "1.0-1", 1},
+		{"1.0", "1.0-0", 0},
+		{"1.0-a", "1.0", 1},
+		{"1.0-2-3", "1.0-2-4", -1},
+		// tildes, from the Debian policy example ~~ < ~~a < ~ < "" < a
+		{"1.0~~", "1.0~~a", -1},
+		{"1.0~~a", "1.0~", -1},
+		{"1.0~", "1.0", -1},
+		{"1.0", "1.0a", -1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc1-1", "1.0-1", -1},
+		{"1.0-1~bpo1", "1.0-1", -1},
+	}
+
+	for _, tc := range testCases {
+		d1, err := NewDebian(tc.v1)
+		require.NoError(err, "should create the version %q", tc.v1)
+		d2, err := NewDebian(tc.v2)
+		require.NoError(err, "should create the version %q", tc.v2)
+
+		require.Equal(tc.rslt, d1.Compare(d2), "Compare(%s, %s)", tc.v1, tc.v2)
+		require.Equal(-tc.rslt, d2.Compare(d1), "Compare(%s, %s)", tc.v2, tc.v1)
+		require.Equal(tc.rslt == -1, d1.LessThan(d2), "LessThan(%s, %s)", tc.v1, tc.v2)
+		require.Equal(tc.rslt == 1, d1.GreaterThan(d2), "GreaterThan(%s, %s)", tc.v1, tc.v2)
+		require.Equal(tc.rslt == 0, d1.Equal(d2), "Equal(%s, %s)", tc.v1, tc.v2)
+	}
+}
+
+func TestNewDebian(t *testing.T) {
+	require := require.New(t)
+
+	d, err := NewDebian("1:2.30-0ubuntu1~20.04")
+	require.NoError(err, "should create the version")
+	require.Equal("1:2.30-0ubuntu1~20.04", d.String(), "should have the expected string")
+	require.Equal(1, d.Epoch(), "should have the epoch")
+	require.Equal("2.30", d.Upstream(), "should have the upstream version")
+	require.Equal("0ubuntu1~20.04", d.Revision(), "should have the revision")
+
+	d, err = NewDebian("1.2-3-4")
+	require.NoError(err, "should create the version")
+	require.Equal("1.2-3", d.Upstream(), "the revision follows the last -")
+	require.Equal("4", d.Revision(), "the revision follows the last -")
+
+	d, err = NewDebian("2:1.0:1")
+	require.NoError(err, "should allow a colon after the epoch")
+	require.Equal("1.0:1", d.Upstream(), "the epoch is before the first :")
+
+	for _, s := range []string{
+		"1.0-",
+		"-1",
+		":1.0",
+		"a:1.0",
+		"99999999999999999999:1.0",
+		"a1.0",
+		"1.0 1",
+		" 1.0",
+		"1.0_1",
+		"1.0-1:1",
+		"1.0-1_1",
+	} {
+		d, err := NewDebian(s)
+		require.Nil(d, "%q should not return a version", s)
+		require.ErrorIs(err, InvalidDebian, "%q should return the expected error", s)
+	}
+	_, err = NewDebian("")
+	require.Equal(InvalidVersion, err, "should reject an empty string")
+}
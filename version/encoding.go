@@ -0,0 +1,134 @@
+package version
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalText - encode the version as its string, for encoding.TextMarshaler
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText - decode a version string, for encoding.TextUnmarshaler.
+//  A string with a pre-release or build metadata is parsed as SemVer, any
+//  other as NewVersion. The options of v are kept.
+//  Returns the error from parsing, a *ParseError with the string, if it is
+//  not valid
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := parseConstraintVersion(string(text), v.options())
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// MarshalJSON - encode the version as a JSON string, for json.Marshaler
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON - decode a version from a JSON string, as UnmarshalText, for
+//  json.Unmarshaler. A JSON null leaves the version unchanged
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s is not a JSON string", InvalidVersion, data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Value - the version as a string, for driver.Valuer
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// Scan - decode a version from a string or []byte column, as UnmarshalText,
+//  for sql.Scanner. Scan into a **Version for a column that can be NULL
+func (v *Version) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return v.UnmarshalText([]byte(src))
+	case []byte:
+		return v.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("%w: cannot scan NULL", InvalidVersion)
+	}
+	return fmt.Errorf("%w: cannot scan %T", InvalidVersion, src)
+}
+
+// sort key bytes, each marker is lower than the ones after it
+const (
+	sortPrerelease byte = iota + 1 // levels end, pre-release identifiers follow
+	sortRelease                    // levels end, no pre-release
+	sortLevel                      // another level follows
+)
+
+// sort key bytes, for pre-release identifiers
+const (
+	sortEndAlpha byte = 0 // ends an alphanumeric identifier
+	sortNumeric  byte = 1 // a numeric identifier follows
+	sortAlpha    byte = 2 // an alphanumeric identifier follows
+)
+
+// SortKey - a binary encoding of the version, where the byte order of the keys
+//  is the order of Compare, so a database column of them, such as a Postgres
+//  bytea, sorts as the versions do.
+//  - levels the policy ignores are left out, so equal versions have equal keys
+//  - build metadata is left out, as Compare ignores it
+//  Keys only sort as Compare for versions with the same policy
+func (v Version) SortKey() []byte {
+	levels := v.asArray
+	if v.policy == Significant && len(levels) > v.levels {
+		levels = levels[:v.levels]
+	}
+	if v.policy != Strict {
+		// missing levels are 0, so trailing 0s do not change the order
+		for len(levels) > 1 && levels[len(levels)-1] == "0" {
+			levels = levels[:len(levels)-1]
+		}
+	}
+
+	key := []byte{}
+	for _, val := range levels {
+		key = append(key, sortLevel)
+		key = appendSortDigits(key, val)
+	}
+	if len(v.pre) == 0 || v.policy == Significant {
+		return append(key, sortRelease)
+	}
+
+	key = append(key, sortPrerelease)
+	for _, id := range v.pre {
+		if isNumeric(id) {
+			key = append(key, sortNumeric)
+			key = appendSortDigits(key, id)
+		} else {
+			key = append(key, sortAlpha)
+			key = append(key, id...)
+			key = append(key, sortEndAlpha)
+		}
+	}
+	return key
+}
+
+// appendSortDigits - append a number without leading zeros, as its length then
+//  its digits, so a longer number sorts after a shorter one. A length of 255 or
+//  more is 0xff then 4 bytes
+func appendSortDigits(key []byte, digits string) []byte {
+	if len(digits) < 0xff {
+		key = append(key, byte(len(digits)))
+	} else {
+		key = append(key, 0xff)
+		n := make([]byte, 4)
+		binary.BigEndian.PutUint32(n, uint32(len(digits)))
+		key = append(key, n...)
+	}
+	return append(key, digits...)
+}
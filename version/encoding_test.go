@@ -0,0 +1,173 @@
+package version
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// compile time checks of the interfaces Version implements
+var (
+	_ encoding.TextMarshaler   = Version{}
+	_ encoding.TextUnmarshaler = &Version{}
+	_ json.Marshaler           = Version{}
+	_ json.Unmarshaler         = &Version{}
+	_ driver.Valuer            = Version{}
+	_ sql.Scanner              = &Version{}
+)
+
+func TestVersionJSON(t *testing.T) {
+	require := require.New(t)
+
+	type config struct {
+		Service string   `json:"service"`
+		Version *Version `json:"version"`
+		Min     Version  `json:"min"`
+	}
+
+	testCases := map[string]struct {
+		data string
+		want string
+		err  error
+	}{
+		"version":           {data: `{"service":"api","version":"1.2.3.4","min":"1.0"}`, want: "1.2.3.4"},
+		"semver":            {data: `{"service":"api","version":"1.2.3-rc.1+build.5","min":"1.0"}`, want: "1.2.3-rc.1+build.5"},
+		"null":              {data: `{"service":"api","version":null,"min":"1.0"}`},
+		"bad element":       {data: `{"service":"api","version":"1.a","min":"1.0"}`, err: InvalidElement},
+		"missing element":   {data: `{"service":"api","version":"1..2","min":"1.0"}`, err: InvalidSeparatorUse},
+		"bad pre-release":   {data: `{"service":"api","version":"1.2.3-rc..1","min":"1.0"}`, err: InvalidPrerelease},
+		"semver levels":     {data: `{"service":"api","version":"1.2-rc.1","min":"1.0"}`, err: InvalidSemVer},
+		"empty":             {data: `{"service":"api","version":"","min":"1.0"}`, err: InvalidVersion},
+		"not a string":      {data: `{"service":"api","version":1.2,"min":"1.0"}`, err: InvalidVersion},
+		"bad value, no ptr": {data: `{"service":"api","version":"1.2","min":"x"}`, err: InvalidElement},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cfg config
+			err := json.Unmarshal([]byte(tc.data), &cfg)
+			require.ErrorIs(err, tc.err)
+			if tc.err != nil {
+				return
+			}
+			if tc.want == "" {
+				require.Nil(cfg.Version)
+				return
+			}
+			require.Equal(tc.want, cfg.Version.String())
+
+			data, err := json.Marshal(cfg)
+			require.NoError(err)
+			require.JSONEq(tc.data, string(data))
+		})
+	}
+}
+
+func TestVersionUnmarshalKeepsPolicy(t *testing.T) {
+	require := require.New(t)
+
+	v, err := NewVersion("1", WithPolicy(ZeroPadded))
+	require.NoError(err)
+	require.NoError(v.UnmarshalText([]byte("1.2")))
+
+	other, err := NewVersion("1.2.0")
+	require.NoError(err)
+	require.Equal("1.2", v.String())
+	require.True(v.Equal(other))
+
+	var parseErr *ParseError
+	err = v.UnmarshalText([]byte("1..2"))
+	require.True(errors.As(err, &parseErr), "should return the ParseError")
+	require.Equal("1..2", parseErr.Input)
+	require.Equal("1.2", v.String(), "should keep the version on an error")
+}
+
+func TestVersionSQL(t *testing.T) {
+	require := require.New(t)
+
+	v, err := NewSemVer("1.2.3-rc.1")
+	require.NoError(err)
+	value, err := v.Value()
+	require.NoError(err)
+	require.Equal("1.2.3-rc.1", value)
+
+	testCases := map[string]struct {
+		src  any
+		want string
+		err  error
+	}{
+		"string":  {src: "1.2.3.4", want: "1.2.3.4"},
+		"bytes":   {src: []byte("2.0.0-beta"), want: "2.0.0-beta"},
+		"null":    {src: nil, err: InvalidVersion},
+		"integer": {src: int64(1), err: InvalidVersion},
+		"invalid": {src: "1.x", err: InvalidElement},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var scanned Version
+			err := scanned.Scan(tc.src)
+			require.ErrorIs(err, tc.err)
+			if tc.err != nil {
+				return
+			}
+			require.Equal(tc.want, scanned.String())
+		})
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	require := require.New(t)
+
+	versions := []string{
+		"0", "0.0.0-rc", "0.0.1", "1", "1.0", "1.0.0", "1.0.0.0", "1.0.0-1", "1.0.0-2",
+		"1.0.0-10", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-alpha-1",
+		"1.0.0-Alpha", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1",
+		"1.0.0+build", "1.0.1", "1.2", "1.2.3.4.5", "1.10", "2", "9.9.9", "10.0.0",
+		"123456789.0", "1234567890123456789.1",
+	}
+
+	testCases := map[string][]Option{
+		"strict":        {},
+		"zero padded":   {WithPolicy(ZeroPadded)},
+		"2 significant": {WithSignificantLevels(2)},
+		"4 significant": {WithSignificantLevels(4)},
+	}
+
+	for name, opts := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed := []*Version{}
+			for _, s := range versions {
+				v, err := NewSemVer(s, opts...)
+				if err != nil {
+					v, err = NewVersion(s, opts...)
+				}
+				require.NoError(err, s)
+				parsed = append(parsed, v)
+			}
+
+			for _, v1 := range parsed {
+				for _, v2 := range parsed {
+					require.Equal(v1.Compare(v2), bytes.Compare(v1.SortKey(), v2.SortKey()),
+						"%s and %s", v1, v2)
+				}
+			}
+		})
+	}
+}
+
+func TestSortKeyLongLevel(t *testing.T) {
+	require := require.New(t)
+
+	short := appendSortDigits(nil, "9")
+	long := appendSortDigits(nil, string(bytes.Repeat([]byte("1"), 300)))
+	longer := appendSortDigits(nil, string(bytes.Repeat([]byte("1"), 301)))
+	require.Equal(-1, bytes.Compare(short, long))
+	require.Equal(-1, bytes.Compare(long, longer))
+}
@@ -0,0 +1,259 @@
+package version
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var InvalidGoModule = fmt.Errorf("Invalid Go module version string")
+
+// pseudoPattern - the forms of a pseudo-version, as golang.org/x/mod/module
+var pseudoPattern = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// pseudoTimeFormat - the layout of the timestamp in a pseudo-version, UTC
+const pseudoTimeFormat = "20060102150405"
+
+// GoModule - a Go module version, a SemVer version with a mandatory 'v'
+//  prefix, compared as golang.org/x/mod/semver, eg v1.2.3 or
+//  v2.0.1+incompatible.
+//  - v1 and v1.2 are short for v1.0.0 and v1.2.0, and cannot have a
+//    pre-release or build metadata
+//  - build metadata, such as +incompatible, is ignored when comparing
+//  - a pseudo-version, such as v0.0.0-20220101120000-abcdef123456, is a
+//    pre-release of the version after its base, so orders after its base
+//    and by its timestamp
+type GoModule struct {
+	asString string
+	semver   *Version
+}
+
+// NewGoModule - create a GoModule from a Go module version string
+func NewGoModule(s string) (*GoModule, error) {
+	if s == "" {
+		return nil, InvalidVersion
+	}
+	if s[0] != 'v' {
+		return nil, fmt.Errorf("%w: %q does not start with v", InvalidGoModule, s)
+	}
+
+	full := s[1:]
+	core := full
+	if i := strings.IndexAny(full, "-+"); i >= 0 {
+		core = full[:i]
+	}
+	if n := strings.Count(core, "."); n < 2 {
+		if core != full {
+			return nil, fmt.Errorf("%w: %q is short for %s.0, so cannot have a pre-release or build", InvalidGoModule, s, s)
+		}
+		full += strings.Repeat(".0", 2-n)
+	}
+
+	ver, err := NewSemVer(full)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", InvalidGoModule, s, err)
+	}
+	return &GoModule{asString: s, semver: ver}, nil
+}
+
+// String - print the version as a string
+func (g GoModule) String() string {
+	return g.asString
+}
+
+// Canonical - print the version in full, without build metadata, eg v1.2
+//  is v1.2.0 and v1.2.3+meta is v1.2.3, as semver.Canonical
+func (g GoModule) Canonical() string {
+	s := "v" + strings.Join(g.semver.asArray, ".")
+	if pre := g.semver.Prerelease(); pre != "" {
+		s += "-" + pre
+	}
+	return s
+}
+
+// Major - print the major version, eg v2 for v2.1.0
+func (g GoModule) Major() string {
+	return "v" + g.semver.asArray[0]
+}
+
+// Prerelease - return the pre-release part of the version, "" if there is none
+func (g GoModule) Prerelease() string {
+	return g.semver.Prerelease()
+}
+
+// Metadata - return the build metadata of the version, "" if there is none
+func (g GoModule) Metadata() string {
+	return g.semver.Metadata()
+}
+
+// Incompatible - check if the version is +incompatible, a major version
+//  of 2 or more from before the module used modules
+func (g GoModule) Incompatible() bool {
+	return g.semver.Metadata() == "incompatible"
+}
+
+// IsPseudo - check if the version is a pseudo-version, one the go command
+//  makes for a commit with no tag
+func (g GoModule) IsPseudo() bool {
+	_, _, _, ok := g.parsePseudo()
+	return ok
+}
+
+// parsePseudo - split a pseudo-version into its base, timestamp and
+//  revision, as golang.org/x/mod/module's parsePseudoVersion
+func (g GoModule) parsePseudo() (string, string, string, bool) {
+	if strings.Count(g.asString, "-") < 2 || !pseudoPattern.MatchString(g.asString) {
+		return "", "", "", false
+	}
+
+	v := g.asString
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	j := strings.LastIndex(v, "-")
+	v, rev := v[:j], v[j+1:]
+	i := strings.LastIndex(v, "-")
+	if j := strings.LastIndex(v, "."); j > i {
+		// vX.Y.Z-pre.0.yyyymmddhhmmss or vX.Y.(Z+1)-0.yyyymmddhhmmss
+		base, timestamp := v[:j], v[j+1:]
+		if strings.HasSuffix(base, "-0") && g.semver.asArray[2] == "0" {
+			// there is no patch before 0 for this to follow
+			return "", "", "", false
+		}
+		return base, timestamp, rev, true
+	}
+	// vX.0.0-yyyymmddhhmmss
+	return v[:i], v[i+1:], rev, true
+}
+
+// PseudoTime - return the commit time of a pseudo-version
+func (g GoModule) PseudoTime() (time.Time, error) {
+	_, timestamp, _, ok := g.parsePseudo()
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %q is not a pseudo-version", InvalidGoModule, g.asString)
+	}
+	return time.Parse(pseudoTimeFormat, timestamp)
+}
+
+// PseudoRevision - return the commit hash prefix of a pseudo-version
+func (g GoModule) PseudoRevision() (string, error) {
+	_, _, rev, ok := g.parsePseudo()
+	if !ok {
+		return "", fmt.Errorf("%w: %q is not a pseudo-version", InvalidGoModule, g.asString)
+	}
+	return rev, nil
+}
+
+// PseudoBase - return the tagged version a pseudo-version follows, nil
+//  if it follows no tag, as golang.org/x/mod/module's PseudoVersionBase
+//  - v1.2.4-0.20220101120000-abcdef123456 follows v1.2.3
+//  - v1.2.3-pre.0.20220101120000-abcdef123456 follows v1.2.3-pre
+//  - v1.0.0-20220101120000-abcdef123456 follows no tag
+func (g GoModule) PseudoBase() (*GoModule, error) {
+	base, _, _, ok := g.parsePseudo()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not a pseudo-version", InvalidGoModule, g.asString)
+	}
+
+	build := ""
+	if meta := g.Metadata(); meta != "" {
+		build = "+" + meta
+	}
+	switch {
+	case !strings.Contains(base, "-"):
+		return nil, nil
+	case strings.HasSuffix(base, "-0"):
+		levels := g.semver.asArray
+		base = fmt.Sprintf("v%s.%s.%s", levels[0], levels[1], decrementDigits(levels[2]))
+	default:
+		base = strings.TrimSuffix(base, ".0")
+	}
+	return NewGoModule(base + build)
+}
+
+// decrementDigits - subtract one from a number above 0 held as a string of
+//  digits without leading zeros, of any length
+func decrementDigits(s string) string {
+	digits := []byte(s)
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] > '0' {
+			digits[i]--
+			break
+		}
+		digits[i] = '9'
+	}
+	return trimZeros(string(digits))
+}
+
+// LessThan - check if version is less than another version
+func (g GoModule) LessThan(g2 *GoModule) bool {
+	return g.Compare(g2) == -1
+}
+
+// GreaterThan - check if version is greater than another version
+func (g GoModule) GreaterThan(g2 *GoModule) bool {
+	return g.Compare(g2) == 1
+}
+
+// Equal - check if version is equal to another version
+func (g GoModule) Equal(g2 *GoModule) bool {
+	return g.Compare(g2) == 0
+}
+
+// Compare - compare two versions, return 0 if they are equal, -1 if g < g2, or 1 if g > g2
+//  Compares as SemVer, so build metadata is ignored and v1.2 == v1.2.0
+func (g GoModule) Compare(g2 *GoModule) int {
+	return g.semver.Compare(g2.semver)
+}
+
+// GoModRequirements - the module versions required by a go.mod file, by
+//  module path, from both single line and block require directives
+func GoModRequirements(data []byte) (map[string]*GoModule, error) {
+	required := map[string]*GoModule{}
+	inBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+
+		switch {
+		case inBlock && len(fields) == 1 && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+		case len(fields) == 2 && fields[0] == "require" && fields[1] == "(":
+			inBlock = true
+			continue
+		case len(fields) > 0 && fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: go.mod line %d is not a module path and version", InvalidGoModule, line)
+		}
+		ver, err := NewGoModule(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("go.mod line %d: %w", line, err)
+		}
+		required[strings.Trim(fields[0], "\"`")] = ver
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inBlock {
+		return nil, fmt.Errorf("%w: go.mod has an unclosed require block", InvalidGoModule)
+	}
+	return required, nil
+}
@@ -0,0 +1,219 @@
+package version
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoModuleOrder(t *testing.T) {
+	require := require.New(t)
+
+	// the versions from golang.org/x/mod/semver's tests, each with its
+	//  canonical form, in increasing order
+	testCases := []struct {
+		in  string
+		out string
+	}{
+		{"v1.0.0-alpha", "v1.0.0-alpha"},
+		{"v1.0.0-alpha.1", "v1.0.0-alpha.1"},
+		{"v1.0.0-alpha.beta", "v1.0.0-alpha.beta"},
+		{"v1.0.0-beta", "v1.0.0-beta"},
+		{"v1.0.0-beta.2", "v1.0.0-beta.2"},
+		{"v1.0.0-beta.11", "v1.0.0-beta.11"},
+		{"v1.0.0-rc.1", "v1.0.0-rc.1"},
+		{"v1", "v1.0.0"},
+		{"v1.0", "v1.0.0"},
+		{"v1.0.0", "v1.0.0"},
+		{"v1.2", "v1.2.0"},
+		{"v1.2.0", "v1.2.0"},
+		{"v1.2.3-456", "v1.2.3-456"},
+		{"v1.2.3-456.789", "v1.2.3-456.789"},
+		{"v1.2.3-456-789", "v1.2.3-456-789"},
+		{"v1.2.3-456a", "v1.2.3-456a"},
+		{"v1.2.3-pre", "v1.2.3-pre"},
+		{"v1.2.3-pre+meta", "v1.2.3-pre"},
+		{"v1.2.3-pre.1", "v1.2.3-pre.1"},
+		{"v1.2.3-zzz", "v1.2.3-zzz"},
+		{"v1.2.3", "v1.2.3"},
+		{"v1.2.3+meta", "v1.2.3"},
+		{"v1.2.3+meta-pre", "v1.2.3"},
+		{"v1.2.3+meta-pre.sha.256a", "v1.2.3"},
+	}
+
+	versions := []*GoModule{}
+	for _, tc := range testCases {
+		g, err := NewGoModule(tc.in)
+		require.NoError(err, "should create the version %q", tc.in)
+		require.Equal(tc.in, g.String(), "should keep the version as given")
+		require.Equal(tc.out, g.Canonical(), "%q should have the canonical form", tc.in)
+		versions = append(versions, g)
+	}
+	for i, g1 := range versions {
+		for j, g2 := range versions {
+			// versions with the same canonical form are equal
+			want := compareInt(i, j)
+			if g1.Canonical() == g2.Canonical() {
+				want = 0
+			}
+			require.Equal(want, g1.Compare(g2), "%s against %s", g1, g2)
+			require.Equal(want == -1, g1.LessThan(g2), "%s less than %s", g1, g2)
+			require.Equal(want == 1, g1.GreaterThan(g2), "%s greater than %s", g1, g2)
+			require.Equal(want == 0, g1.Equal(g2), "%s equal to %s", g1, g2)
+		}
+	}
+
+	for _, s := range []string{
+		"bad",
+		"1.2.3",
+		"v",
+		"v1-alpha.beta.gamma",
+		"v1-pre",
+		"v1+meta",
+		"v1-pre+meta",
+		"v1.2-pre",
+		"v1.2+meta",
+		"v1.2-pre+meta",
+		"v01.2.3",
+		"v1.2.3.4",
+		"v1.2.3-01",
+	} {
+		g, err := NewGoModule(s)
+		require.Nil(g, "%q should not return a version", s)
+		require.ErrorIs(err, InvalidGoModule, "%q should return the expected error", s)
+	}
+	_, err := NewGoModule("")
+	require.Equal(InvalidVersion, err, "should reject an empty string")
+}
+
+func TestGoModulePseudo(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		ver  string
+		base string
+		time string
+		rev  string
+	}{
+		"no base": {
+			ver:  "v0.0.0-20220101120000-abcdef123456",
+			time: "2022-01-01T12:00:00Z",
+			rev:  "abcdef123456",
+		},
+		"release base": {
+			ver:  "v1.2.4-0.20220101120000-abcdef123456",
+			base: "v1.2.3",
+			time: "2022-01-01T12:00:00Z",
+			rev:  "abcdef123456",
+		},
+		"release base, borrowing": {
+			ver:  "v1.2.100-0.20220101120000-abcdef123456",
+			base: "v1.2.99",
+			time: "2022-01-01T12:00:00Z",
+			rev:  "abcdef123456",
+		},
+		"pre-release base": {
+			ver:  "v1.2.3-pre.0.20211231235959-0123456789ab",
+			base: "v1.2.3-pre",
+			time: "2021-12-31T23:59:59Z",
+			rev:  "0123456789ab",
+		},
+		"incompatible": {
+			ver:  "v2.1.1-0.20220101120000-abcdef123456+incompatible",
+			base: "v2.1.0+incompatible",
+			time: "2022-01-01T12:00:00Z",
+			rev:  "abcdef123456",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			g, err := NewGoModule(tc.ver)
+			require.NoError(err, "should create the version")
+			require.True(g.IsPseudo(), "should be a pseudo-version")
+
+			base, err := g.PseudoBase()
+			require.NoError(err, "should get the base")
+			if tc.base == "" {
+				require.Nil(base, "should have no base")
+			} else {
+				require.Equal(tc.base, base.String(), "should have the base")
+				require.True(base.LessThan(g), "should be after its base")
+			}
+
+			ts, err := g.PseudoTime()
+			require.NoError(err, "should get the time")
+			require.Equal(tc.time, ts.Format(time.RFC3339), "should have the time")
+			rev, err := g.PseudoRevision()
+			require.NoError(err, "should get the revision")
+			require.Equal(tc.rev, rev, "should have the revision")
+		})
+	}
+
+	// pseudo-versions order after their base, by timestamp, and before the next tag
+	ordered := []string{
+		"v1.2.3",
+		"v1.2.4-0.20210101000000-ffffffffffff",
+		"v1.2.4-0.20220101120000-000000000000",
+		"v1.2.4-0.20220101120001-aaaaaaaaaaaa",
+		"v1.2.4",
+	}
+	for i := 1; i < len(ordered); i++ {
+		g1, _ := NewGoModule(ordered[i-1])
+		g2, _ := NewGoModule(ordered[i])
+		require.True(g1.LessThan(g2), "%s should be before %s", g1, g2)
+	}
+
+	for _, s := range []string{"v1.2.3", "v1.2.3-pre", "v1.2.0-0.20220101120000-abcdef123456", "v1.2.4-0.2022010112000-abcdef123456"} {
+		g, _ := NewGoModule(s)
+		require.False(g.IsPseudo(), "%s should not be a pseudo-version", s)
+		_, err := g.PseudoTime()
+		require.ErrorIs(err, InvalidGoModule, "%s should have no time", s)
+		_, err = g.PseudoBase()
+		require.ErrorIs(err, InvalidGoModule, "%s should have no base", s)
+	}
+
+	g, _ := NewGoModule("v2.0.1+incompatible")
+	require.True(g.Incompatible(), "should be incompatible")
+	require.Equal("v2", g.Major(), "should have the major version")
+	plain, _ := NewGoModule("v2.0.1")
+	require.False(plain.Incompatible(), "should not be incompatible")
+	require.True(g.Equal(plain), "+incompatible is ignored when comparing")
+}
+
+func TestGoModRequirements(t *testing.T) {
+	require := require.New(t)
+
+	mods := map[string]map[string]*GoModule{}
+	for _, file := range []string{"go.mod", "../maths/go.mod"} {
+		data, err := os.ReadFile(file)
+		require.NoError(err, "should read %s", file)
+		mods[file], err = GoModRequirements(data)
+		require.NoError(err, "should parse %s", file)
+	}
+
+	require.Len(mods["go.mod"], 4, "should find every requirement")
+	require.Equal("v1.8.0", mods["go.mod"]["github.com/stretchr/testify"].String(), "should get the testify version")
+	require.Equal("v3.0.1", mods["go.mod"]["gopkg.in/yaml.v3"].String(), "should get an indirect version")
+	require.Contains(mods["../maths/go.mod"], "github.com/gorilla/mux", "should find a block requirement")
+	for path, ver := range mods["go.mod"] {
+		require.True(ver.Equal(mods["../maths/go.mod"][path]), "the modules should require the same %s", path)
+	}
+
+	data := []byte("module example.com/m\n\ngo 1.21\n\nrequire golang.org/x/mod v0.14.0\nrequire \"example.com/q\" v0.0.0-20220101120000-abcdef123456 // indirect\n")
+	req, err := GoModRequirements(data)
+	require.NoError(err, "should parse single line requirements")
+	require.Equal("v0.14.0", req["golang.org/x/mod"].String(), "should get a single line requirement")
+	require.True(req["example.com/q"].IsPseudo(), "should get a quoted pseudo-version requirement")
+
+	for _, bad := range []string{
+		"require example.com/m 1.2.3\n",
+		"require example.com/m\n",
+		"require (\n\texample.com/m v1.2.3\n",
+	} {
+		_, err := GoModRequirements([]byte(bad))
+		require.ErrorIs(err, InvalidGoModule, "should reject %q", bad)
+	}
+}
@@ -0,0 +1,277 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// mavenQualifiers - the well-known qualifiers, in order, "" being a release
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenAliases - alternative spellings of the well-known qualifiers
+var mavenAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+// mavenKind - the kind of an item of a Maven version
+type mavenKind int
+
+const (
+	mavenInt mavenKind = iota
+	mavenString
+	mavenList
+)
+
+// mavenItem - an item of a Maven version, a number, a qualifier, or a list
+//  of items following a '-' or a change between digits and letters
+type mavenItem struct {
+	kind  mavenKind
+	value string // digits without leading zeros, or the qualifier
+	list  []*mavenItem
+}
+
+// Maven - a Maven or Gradle artifact version, eg 1.0-SNAPSHOT or
+//  1.0-alpha-2, compared as Maven's ComparableVersion.
+//  The version is split into numbers and qualifiers at '.', '-' and
+//  between digits and letters, and compared item by item:
+//  - numbers compare as numbers, and missing ones are 0, so 1 == 1.0.0
+//  - qualifiers order alpha < beta < milestone < rc < snapshot < "" < sp,
+//    with any other qualifier after them, in alphabetical order.
+//    a, b and m directly followed by a number are alpha, beta and milestone,
+//    ga, final and release are "", and cr is rc
+//  - numbers are greater than qualifiers, so 1-sp < 1.1
+//  Any string is a valid Maven version
+type Maven struct {
+	asString string
+	items    *mavenItem
+}
+
+// NewMaven - create a Maven version from a version string
+func NewMaven(s string) (*Maven, error) {
+	if s == "" {
+		return nil, InvalidVersion
+	}
+	return &Maven{asString: s, items: parseMaven(s)}, nil
+}
+
+// parseMaven - split a version into its items, as ComparableVersion's
+//  parseVersion
+func parseMaven(s string) *mavenItem {
+	version := strings.ToLower(s)
+	root := &mavenItem{kind: mavenList}
+	list := root
+	stack := []*mavenItem{root}
+
+	// sublist - start a new list inside the current one
+	sublist := func() {
+		next := &mavenItem{kind: mavenList}
+		list.list = append(list.list, next)
+		list = next
+		stack = append(stack, next)
+	}
+	zero := func() *mavenItem {
+		return &mavenItem{kind: mavenInt}
+	}
+
+	isDigit := false
+	start := 0
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case c == '.':
+			if i == start {
+				list.list = append(list.list, zero())
+			} else {
+				list.list = append(list.list, mavenParseItem(isDigit, false, version[start:i]))
+			}
+			start = i + 1
+		case c == '-':
+			if i == start {
+				list.list = append(list.list, zero())
+			} else {
+				list.list = append(list.list, mavenParseItem(isDigit, false, version[start:i]))
+			}
+			start = i + 1
+			sublist()
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				list.list = append(list.list, mavenParseItem(false, true, version[start:i]))
+				start = i
+				sublist()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				list.list = append(list.list, mavenParseItem(true, false, version[start:i]))
+				start = i
+				sublist()
+			}
+			isDigit = false
+		}
+	}
+	if len(version) > start {
+		list.list = append(list.list, mavenParseItem(isDigit, false, version[start:]))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return root
+}
+
+// mavenParseItem - a number or qualifier item. A single letter qualifier
+//  followed by a digit can be short for alpha, beta or milestone
+func mavenParseItem(isDigit, followedByDigit bool, buf string) *mavenItem {
+	if isDigit {
+		return &mavenItem{kind: mavenInt, value: strings.TrimLeft(buf, "0")}
+	}
+	if followedByDigit && len(buf) == 1 {
+		switch buf {
+		case "a":
+			buf = "alpha"
+		case "b":
+			buf = "beta"
+		case "m":
+			buf = "milestone"
+		}
+	}
+	if alias, found := mavenAliases[buf]; found {
+		buf = alias
+	}
+	return &mavenItem{kind: mavenString, value: buf}
+}
+
+// normalize - remove the trailing items of a list that are the same as
+//  nothing, 0, "" or an empty list, stepping over any trailing lists
+func (m *mavenItem) normalize() {
+	for i := len(m.list) - 1; i >= 0; i-- {
+		item := m.list[i]
+		if item.isNull() {
+			m.list = append(m.list[:i], m.list[i+1:]...)
+		} else if item.kind != mavenList {
+			break
+		}
+	}
+}
+
+// isNull - check if the item is the same as nothing
+func (m *mavenItem) isNull() bool {
+	if m.kind == mavenList {
+		return len(m.list) == 0
+	}
+	return m.value == ""
+}
+
+// comparableQualifier - a string that orders qualifiers, the position of
+//  the well-known qualifiers, with any others after them alphabetically
+func comparableQualifier(q string) string {
+	for i, known := range mavenQualifiers {
+		if q == known {
+			return strconv.Itoa(i)
+		}
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + q
+}
+
+// releaseQualifier - the comparable qualifier of a release, ""
+var releaseQualifier = comparableQualifier("")
+
+// compare - compare two items, where other can be nil for a missing item,
+//  return 0 if they are equal, -1 if m < other, or 1 if m > other
+func (m *mavenItem) compare(other *mavenItem) int {
+	switch m.kind {
+	case mavenInt:
+		if other == nil {
+			// 1.0 == 1, 1.1 > 1
+			if m.value == "" {
+				return 0
+			}
+			return 1
+		}
+		if other.kind != mavenInt {
+			// 1.1 > 1-sp, 1.1 > 1-1
+			return 1
+		}
+		if len(m.value) != len(other.value) {
+			return compareInt(len(m.value), len(other.value))
+		}
+		return strings.Compare(m.value, other.value)
+
+	case mavenString:
+		if other == nil {
+			// 1-rc < 1, 1-sp > 1
+			return strings.Compare(comparableQualifier(m.value), releaseQualifier)
+		}
+		if other.kind != mavenString {
+			// 1.any < 1.1, 1.any < 1-1
+			return -1
+		}
+		return strings.Compare(comparableQualifier(m.value), comparableQualifier(other.value))
+	}
+
+	if other == nil {
+		// compare every item with nothing, 1-0 == 1
+		for _, item := range m.list {
+			if c := item.compare(nil); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+	switch other.kind {
+	case mavenInt:
+		// 1-1 < 1.0.x
+		return -1
+	case mavenString:
+		// 1-1 > 1-sp
+		return 1
+	}
+	for i := 0; i < len(m.list) || i < len(other.list); i++ {
+		var left, right *mavenItem
+		if i < len(m.list) {
+			left = m.list[i]
+		}
+		if i < len(other.list) {
+			right = other.list[i]
+		}
+		c := 0
+		switch {
+		case left != nil:
+			c = left.compare(right)
+		case right != nil:
+			c = -right.compare(nil)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// String - print the version as a string
+func (m Maven) String() string {
+	return m.asString
+}
+
+// LessThan - check if version is less than another version
+func (m Maven) LessThan(m2 *Maven) bool {
+	return m.Compare(m2) == -1
+}
+
+// GreaterThan - check if version is greater than another version
+func (m Maven) GreaterThan(m2 *Maven) bool {
+	return m.Compare(m2) == 1
+}
+
+// Equal - check if version is equal to another version
+func (m Maven) Equal(m2 *Maven) bool {
+	return m.Compare(m2) == 0
+}
+
+// Compare - compare two versions, return 0 if they are equal, -1 if m < m2, or 1 if m > m2
+func (m Maven) Compare(m2 *Maven) int {
+	return sign(m.items.compare(m2.items))
+}
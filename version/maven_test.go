@@ -0,0 +1,161 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireMavenOrder - check each version is lower than the next, and that
+//  comparing any two agrees
+func requireMavenOrder(require *require.Assertions, ordered []string) {
+	versions := []*Maven{}
+	for _, s := range ordered {
+		m, err := NewMaven(s)
+		require.NoError(err, "should create the version %q", s)
+		versions = append(versions, m)
+	}
+	for i, m1 := range versions {
+		for j, m2 := range versions {
+			require.Equal(compareInt(i, j), m1.Compare(m2), "%s against %s", m1, m2)
+		}
+	}
+}
+
+func TestMavenOrder(t *testing.T) {
+	require := require.New(t)
+
+	// the qualifier and number orders from Maven's ComparableVersionTest
+	requireMavenOrder(require, []string{
+		"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2",
+		"1-rc123", "1-SNAPSHOT", "1", "1-sp", "1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot",
+		"1-1", "1-2", "1-123",
+	})
+	requireMavenOrder(require, []string{
+		"2.0", "2-1", "2.0.a", "2.0.0.a", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c", "2.1-1", "2.1.0.1",
+		"2.2", "2.123", "11.a2", "11.a11", "11.b2", "11.b11", "11.m2", "11.m11", "11", "11.a", "11b", "11c", "11m",
+	})
+	requireMavenOrder(require, []string{
+		"1.0-alpha-1", "1.0-alpha-2", "1.0-beta-1", "1.0-milestone-1", "1.0-rc-1", "1.0-SNAPSHOT", "1.0",
+		"1.0-sp", "1.0-sp-1", "1.0.1", "1.1",
+	})
+}
+
+func TestMavenEqual(t *testing.T) {
+	require := require.New(t)
+
+	// the equal versions from Maven's ComparableVersionTest
+	equal := [][2]string{
+		{"1", "1"}, {"1", "1.0"}, {"1", "1.0.0"}, {"1.0", "1.0.0"}, {"1", "1-0"}, {"1", "1.0-0"}, {"1.0", "1.0-0"},
+		{"1a", "1-a"}, {"1a", "1.0-a"}, {"1a", "1.0.0-a"}, {"1.0a", "1-a"}, {"1.0.0a", "1-a"},
+		{"1x", "1-x"}, {"1x", "1.0-x"}, {"1x", "1.0.0-x"}, {"1.0x", "1-x"}, {"1.0.0x", "1-x"},
+		{"1ga", "1"}, {"1release", "1"}, {"1final", "1"}, {"1cr", "1rc"},
+		{"1a1", "1-alpha-1"}, {"1b2", "1-beta-2"}, {"1m3", "1-milestone-3"},
+		{"1X", "1x"}, {"1A", "1a"}, {"1B", "1b"}, {"1M", "1m"}, {"1Ga", "1"}, {"1GA", "1"}, {"1RELEASE", "1"},
+		{"1RELeaSE", "1"}, {"1Final", "1"}, {"1FinaL", "1"}, {"1FINAL", "1"}, {"1Cr", "1Rc"}, {"1cR", "1rC"},
+		{"1m3", "1Milestone3"}, {"1m3", "1MileStone3"}, {"1m3", "1MILESTONE3"},
+		{"1.0.RELEASE", "1.0"}, {"01.002", "1.2"}, {"1.0-ga", "1.0"},
+	}
+	for _, pair := range equal {
+		m1, _ := NewMaven(pair[0])
+		m2, _ := NewMaven(pair[1])
+		require.True(m1.Equal(m2), "%s should equal %s", pair[0], pair[1])
+		require.True(m2.Equal(m1), "%s should equal %s", pair[1], pair[0])
+		require.False(m1.LessThan(m2), "%s should not be less than %s", pair[0], pair[1])
+		require.False(m1.GreaterThan(m2), "%s should not be greater than %s", pair[0], pair[1])
+	}
+
+	m, _ := NewMaven("1.0-SNAPSHOT")
+	require.Equal("1.0-SNAPSHOT", m.String(), "should keep the version as given")
+	big, _ := NewMaven("1.123456789012345678901234567890")
+	bigger, _ := NewMaven("1.123456789012345678901234567891")
+	require.True(big.LessThan(bigger), "should compare numbers of any length")
+
+	_, err := NewMaven("")
+	require.Equal(InvalidVersion, err, "should reject an empty string")
+}
+
+func TestMavenRange(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		pass []string
+		fail []string
+	}{
+		"[1.0,2.0)": {
+			pass: []string{"1.0", "1.5", "1.9.9", "2.0-SNAPSHOT", "2.0-rc-1"},
+			fail: []string{"0.9", "1.0-SNAPSHOT", "2.0", "2"},
+		},
+		"(1.0,2.0]": {
+			pass: []string{"1.0.1", "2.0", "2"},
+			fail: []string{"1.0", "1", "2.0.1"},
+		},
+		"[1.0]": {
+			pass: []string{"1.0", "1", "1.0.0"},
+			fail: []string{"1.0.1", "0.9"},
+		},
+		"(,1.0]": {
+			pass: []string{"0.1", "1.0"},
+			fail: []string{"1.0.1"},
+		},
+		"[1.5,)": {
+			pass: []string{"1.5", "99"},
+			fail: []string{"1.4"},
+		},
+		"(,1.0],[1.2,)": {
+			pass: []string{"0.9", "1.0", "1.2", "3"},
+			fail: []string{"1.1", "1.2-SNAPSHOT"},
+		},
+		" [1.0 , 1.2) , (1.2,2) ": {
+			pass: []string{"1.1", "1.3"},
+			fail: []string{"1.2", "2"},
+		},
+		"1.0": {
+			pass: []string{"0.1", "1.0", "2.0"},
+		},
+	}
+
+	for str, tc := range testCases {
+		t.Run(str, func(t *testing.T) {
+			r, err := NewMavenRange(str)
+			require.NoError(err, "should create the range")
+			require.Equal(str, r.String(), "MavenRange should have the expected string")
+
+			for _, s := range tc.pass {
+				m, _ := NewMaven(s)
+				require.True(r.Check(m), "%s should satisfy %s", s, str)
+			}
+			for _, s := range tc.fail {
+				m, _ := NewMaven(s)
+				require.False(r.Check(m), "%s should not satisfy %s", s, str)
+				require.ErrorIs(r.Validate(m), ConstraintNotMet, "%s should not satisfy %s", s, str)
+			}
+		})
+	}
+
+	r, _ := NewMavenRange("(,1.0],[1.2,)")
+	m, _ := NewMaven("1.1")
+	require.EqualError(r.Validate(m), `Version does not satisfy constraint "(,1.0],[1.2,)": 1.1 is above (,1.0], or 1.1 is below [1.2,)`, "should explain the failure")
+
+	for _, s := range []string{
+		"",
+		"[1.0,2.0",
+		"1.0,2.0)",
+		"(1.0)",
+		"[1.0)",
+		"[]",
+		"[2.0,1.0]",
+		"(1.0,1.0]",
+		"[1.0,2.0,3.0]",
+		"[1.0,2.0] [3.0]",
+		"[1.0,2.0],",
+		"[1.0,2.0],[1.5,3.0]",
+		"[1.0,2.0],[2.0,3.0]",
+		"[1.0,),[2.0,3.0]",
+		"[2.0,3.0],(,1.0]",
+	} {
+		r, err := NewMavenRange(s)
+		require.Nil(r, "%q should not return a range", s)
+		require.ErrorIs(err, InvalidMavenRange, "%q should return the expected error", s)
+	}
+}
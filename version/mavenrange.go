@@ -0,0 +1,175 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+var InvalidMavenRange = fmt.Errorf("Invalid Maven version range")
+
+// MavenRange - a Maven version range, one or more restrictions separated by
+//  ',' that a version must satisfy any one of, eg "(,1.0],[1.2,)"
+//  - [1.0,2.0) is 1.0 <= x < 2.0, a '(' or ')' excludes its bound
+//  - (,1.0] has no lower bound, [1.2,) no upper bound
+//  - [1.0] is exactly 1.0
+//  - 1.0, without brackets, is only a recommendation, so any version will do
+//  Restrictions must be in order and not overlap
+type MavenRange struct {
+	asString     string
+	restrictions []mavenRestriction
+}
+
+// mavenRestriction - one bracketed part of a Maven range, a nil bound is
+//  unbounded
+type mavenRestriction struct {
+	asString       string
+	lower          *Maven
+	lowerInclusive bool
+	upper          *Maven
+	upperInclusive bool
+}
+
+// NewMavenRange - create a MavenRange from a range string
+func NewMavenRange(s string) (*MavenRange, error) {
+	r := &MavenRange{asString: s}
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return nil, fmt.Errorf("%w: %q is empty", InvalidMavenRange, s)
+	}
+
+	if !strings.HasPrefix(rest, "[") && !strings.HasPrefix(rest, "(") {
+		// a recommended version, which any version satisfies
+		if strings.ContainsAny(rest, "[]()") {
+			return nil, fmt.Errorf("%w: %q has unbalanced brackets", InvalidMavenRange, s)
+		}
+		return r, nil
+	}
+
+	for rest != "" {
+		end := strings.IndexAny(rest, "])")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: %q has an unclosed range", InvalidMavenRange, s)
+		}
+		restriction, err := parseMavenRestriction(rest[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", InvalidMavenRange, s, err)
+		}
+		if n := len(r.restrictions); n > 0 {
+			prev := r.restrictions[n-1]
+			if prev.upper == nil || restriction.lower == nil || restriction.lower.LessThan(prev.upper) ||
+				(restriction.lower.Equal(prev.upper) && restriction.lowerInclusive && prev.upperInclusive) {
+				return nil, fmt.Errorf("%w: %q has overlapping ranges", InvalidMavenRange, s)
+			}
+		}
+		r.restrictions = append(r.restrictions, restriction)
+
+		rest = strings.TrimSpace(rest[end+1:])
+		if rest == "" {
+			break
+		}
+		if !strings.HasPrefix(rest, ",") {
+			return nil, fmt.Errorf("%w: %q has text after a range", InvalidMavenRange, s)
+		}
+		rest = strings.TrimSpace(rest[1:])
+		if rest == "" {
+			return nil, fmt.Errorf("%w: %q ends with ','", InvalidMavenRange, s)
+		}
+	}
+
+	return r, nil
+}
+
+// parseMavenRestriction - parse one bracketed restriction, eg [1.0,2.0)
+func parseMavenRestriction(s string) (mavenRestriction, error) {
+	r := mavenRestriction{
+		asString:       s,
+		lowerInclusive: s[0] == '[',
+		upperInclusive: s[len(s)-1] == ']',
+	}
+	if s[0] != '[' && s[0] != '(' {
+		return r, fmt.Errorf("%q does not start with '[' or '('", s)
+	}
+
+	inner := s[1 : len(s)-1]
+	if strings.ContainsAny(inner, "[]()") {
+		return r, fmt.Errorf("%q has unbalanced brackets", s)
+	}
+	bounds := strings.Split(inner, ",")
+	for i := range bounds {
+		bounds[i] = strings.TrimSpace(bounds[i])
+	}
+
+	switch len(bounds) {
+	case 1:
+		// a single version, which must be exact
+		if !r.lowerInclusive || !r.upperInclusive || bounds[0] == "" {
+			return r, fmt.Errorf("%q must be an exact version, [x]", s)
+		}
+		ver, _ := NewMaven(bounds[0])
+		r.lower, r.upper = ver, ver
+		return r, nil
+	case 2:
+	default:
+		return r, fmt.Errorf("%q has more than 2 bounds", s)
+	}
+
+	if bounds[0] != "" {
+		r.lower, _ = NewMaven(bounds[0])
+	}
+	if bounds[1] != "" {
+		r.upper, _ = NewMaven(bounds[1])
+	}
+	if r.lower != nil && r.upper != nil {
+		c := r.lower.Compare(r.upper)
+		if c > 0 || (c == 0 && !(r.lowerInclusive && r.upperInclusive)) {
+			return r, fmt.Errorf("%q has a lower bound above its upper bound", s)
+		}
+	}
+	return r, nil
+}
+
+// String - print the range as a string
+func (r MavenRange) String() string {
+	return r.asString
+}
+
+// Check - check if the version satisfies the range
+func (r MavenRange) Check(m *Maven) bool {
+	return r.Validate(m) == nil
+}
+
+// Validate - check if the version satisfies the range. If it doesn't the
+//  error explains why it failed each restriction
+func (r MavenRange) Validate(m *Maven) error {
+	if len(r.restrictions) == 0 {
+		return nil
+	}
+
+	reasons := []string{}
+	for _, restriction := range r.restrictions {
+		reason := restriction.explain(m)
+		if reason == "" {
+			return nil
+		}
+		reasons = append(reasons, reason)
+	}
+	return fmt.Errorf("%w %q: %s", ConstraintNotMet, r.asString, strings.Join(reasons, ", or "))
+}
+
+// explain - describe why the version is outside the restriction, "" if it
+//  is inside
+func (r mavenRestriction) explain(m *Maven) string {
+	if r.lower != nil {
+		c := m.Compare(r.lower)
+		if c < 0 || (c == 0 && !r.lowerInclusive) {
+			return fmt.Sprintf("%s is below %s", m, r.asString)
+		}
+	}
+	if r.upper != nil {
+		c := m.Compare(r.upper)
+		if c > 0 || (c == 0 && !r.upperInclusive) {
+			return fmt.Sprintf("%s is above %s", m, r.asString)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package version
+
+import (
+	"fmt"
+)
+
+// ParseError - why and where a version string could not be parsed.
+//  Err is the sentinel error, such as InvalidElement, so errors.Is works as
+//  it does for the sentinel
+type ParseError struct {
+	Input  string // the version string
+	Offset int    // the byte offset of the problem in Input
+	Index  int    // the level, 0 based, the problem is in, or the SemVer identifier
+	Err    error
+}
+
+// Error - describe the error and where it is
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%v: %q at offset %d, level %d", e.Err, e.Input, e.Offset, e.Index)
+}
+
+// Unwrap - the sentinel error, for errors.Is
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// lexLevels - split a version string into the digits of its levels, without
+//  leading zeros. Only the digits 0-9 and '.' between levels are allowed, so
+//  signs, whitespace and empty levels are rejected, with where they are
+func lexLevels(s string, zeros LeadingZeros) ([]string, error) {
+	fail := func(offset, index int, err error) ([]string, error) {
+		return nil, &ParseError{Input: s, Offset: offset, Index: index, Err: err}
+	}
+	if s == "" {
+		return fail(0, 0, InvalidVersion)
+	}
+
+	levels := []string{}
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != '.' {
+			if !isDigit(s[i]) {
+				return fail(i, len(levels), InvalidElement)
+			}
+			continue
+		}
+
+		// the end of a level
+		level := s[start:i]
+		switch {
+		case level == "":
+			return fail(i, len(levels), InvalidSeparatorUse)
+		case zeros == RejectLeadingZeros && len(level) > 1 && level[0] == '0':
+			return fail(start, len(levels), InvalidElement)
+		}
+		levels = append(levels, trimZeros(level))
+		start = i + 1
+	}
+	return levels, nil
+}
@@ -0,0 +1,73 @@
+package version
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		ver    string
+		semver bool
+		opts   []Option
+		err    error
+		offset int
+		index  int
+	}{
+		"empty":                {ver: "", err: InvalidVersion, offset: 0, index: 0},
+		"plus sign":            {ver: "+1.-0", err: InvalidElement, offset: 0, index: 0},
+		"minus sign":           {ver: "1.-0", err: InvalidElement, offset: 2, index: 1},
+		"space":                {ver: "1. 2", err: InvalidElement, offset: 2, index: 1},
+		"leading space":        {ver: " 1.2", err: InvalidElement, offset: 0, index: 0},
+		"trailing space":       {ver: "1.2 ", err: InvalidElement, offset: 3, index: 1},
+		"tab":                  {ver: "1.2\t.3", err: InvalidElement, offset: 3, index: 1},
+		"letter":               {ver: "1.2.3a", err: InvalidElement, offset: 5, index: 2},
+		"unicode digit":        {ver: "1.٣", err: InvalidElement, offset: 2, index: 1},
+		"start with .":         {ver: ".1", err: InvalidSeparatorUse, offset: 0, index: 0},
+		"end with .":           {ver: "1.2.", err: InvalidSeparatorUse, offset: 4, index: 2},
+		"missing level":        {ver: "1.2..4", err: InvalidSeparatorUse, offset: 4, index: 2},
+		"leading zero":         {ver: "1.2.03", opts: []Option{WithLeadingZeros(RejectLeadingZeros)}, err: InvalidElement, offset: 4, index: 2},
+		"semver leading zero":  {ver: "1.02.3-rc.1", semver: true, err: InvalidElement, offset: 2, index: 1},
+		"semver space":         {ver: "1.2. 3", semver: true, err: InvalidElement, offset: 4, index: 2},
+		"semver missing level": {ver: "1..3+build", semver: true, err: InvalidSeparatorUse, offset: 2, index: 1},
+		"semver empty":         {ver: "", semver: true, err: InvalidVersion, offset: 0, index: 0},
+		"semver two levels":    {ver: "1.2-rc.1", semver: true, err: InvalidSemVer, offset: 3, index: 2},
+		"semver no levels":     {ver: "-rc.1", semver: true, err: InvalidSemVer, offset: 0, index: 1},
+		"semver four levels":   {ver: "1.2.3.4", semver: true, err: InvalidSemVer, offset: 5, index: 3},
+		"semver bad pre":       {ver: "1.2.3-rc.a_b", semver: true, err: InvalidPrerelease, offset: 10, index: 1},
+		"semver empty pre":     {ver: "1.2.3-rc..1", semver: true, err: InvalidPrerelease, offset: 9, index: 1},
+		"semver pre zero":      {ver: "1.2.3-rc.01", semver: true, err: InvalidPrerelease, offset: 9, index: 1},
+		"semver no pre":        {ver: "1.2.3-", semver: true, err: InvalidPrerelease, offset: 6, index: 0},
+		"semver bad build":     {ver: "1.2.3-rc+b.c+d", semver: true, err: InvalidMetadata, offset: 12, index: 1},
+		"semver empty build":   {ver: "1.2.3+", semver: true, err: InvalidMetadata, offset: 6, index: 0},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if tc.semver {
+				_, err = NewSemVer(tc.ver, tc.opts...)
+			} else {
+				_, err = NewVersion(tc.ver, tc.opts...)
+			}
+			require.ErrorIs(err, tc.err, "should still be the sentinel error")
+
+			var parseErr *ParseError
+			require.True(errors.As(err, &parseErr), "should be a ParseError")
+			require.Equal(tc.ver, parseErr.Input)
+			require.Equal(tc.offset, parseErr.Offset)
+			require.Equal(tc.index, parseErr.Index)
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewVersion("1. 2")
+	require.EqualError(err, `Invalid Version string, non-numeric element: "1. 2" at offset 2, level 1`)
+}
@@ -0,0 +1,307 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var InvalidPEP440 = fmt.Errorf("Invalid PEP 440 version string")
+
+// pep440Pattern - the PEP 440 version grammar, as the packaging library's
+//  VERSION_PATTERN, which accepts the alternative spellings that normalize
+//  to a canonical version
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?` +
+	`\s*$`)
+
+// pep440Pre - the canonical pre-release label for each spelling, and the
+//  order of the labels
+var (
+	pep440Pre = map[string]string{
+		"a": "a", "alpha": "a",
+		"b": "b", "beta": "b",
+		"rc": "rc", "c": "rc", "pre": "rc", "preview": "rc",
+	}
+	pep440PreOrder = map[string]int{"a": 0, "b": 1, "rc": 2}
+)
+
+// PEP440 - a Python package version as defined by PEP 440,
+//  [N!]N(.N)*[{a|b|rc}N][.postN][.devN][+local], eg 1!1.0.post1 or
+//  1.0.dev4+local.7. Alternative spellings, such as 1.0-alpha1 or 1.0-1,
+//  are accepted and normalized, see Normalized.
+//  Ordering is by epoch, then release, ignoring trailing zeros, so
+//  1.0 == 1.0.0, then 1.0.dev1 < 1.0a1 < 1.0b1 < 1.0rc1 < 1.0 < 1.0.post1
+//  and finally by local version, where 1.0 < 1.0+anything
+type PEP440 struct {
+	asString string
+	epoch    int
+	release  []int
+	pre      string // a, b or rc, "" if not a pre-release
+	preN     int
+	post     int // -1 if not a post-release
+	dev      int // -1 if not a dev release
+	local    []string
+}
+
+// NewPEP440 - create a PEP 440 version from a version string
+func NewPEP440(s string) (*PEP440, error) {
+	if s == "" {
+		return nil, InvalidVersion
+	}
+
+	m := pep440Pattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%w: %q", InvalidPEP440, s)
+	}
+	group := func(name string) string {
+		return m[pep440Pattern.SubexpIndex(name)]
+	}
+	// number - an optional number, 0 if it is missing
+	var err error
+	number := func(str string) int {
+		if str == "" || err != nil {
+			return 0
+		}
+		val, e := strconv.Atoi(str)
+		if e != nil {
+			err = fmt.Errorf("%w: %q has a number that is too big", InvalidPEP440, s)
+		}
+		return val
+	}
+
+	p := &PEP440{
+		asString: s,
+		epoch:    number(group("epoch")),
+		post:     -1,
+		dev:      -1,
+	}
+	for _, str := range strings.Split(group("release"), ".") {
+		p.release = append(p.release, number(str))
+	}
+	if label := group("pre_l"); label != "" {
+		p.pre = pep440Pre[strings.ToLower(label)]
+		p.preN = number(group("pre_n"))
+	}
+	if n := group("post_n1"); n != "" {
+		p.post = number(n)
+	} else if group("post_l") != "" {
+		p.post = number(group("post_n2"))
+	}
+	if group("dev_l") != "" {
+		p.dev = number(group("dev_n"))
+	}
+	if local := group("local"); local != "" {
+		p.local = strings.FieldsFunc(strings.ToLower(local), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// String - print the version as a string, as it was given
+func (p PEP440) String() string {
+	return p.asString
+}
+
+// Normalized - print the version in its canonical form, eg 1.0-alpha1 is
+//  1.0a1, v1.0-1 is 1.0.post1 and 1.0+Ubuntu-1 is 1.0+ubuntu.1
+func (p PEP440) Normalized() string {
+	s := p.Public()
+	if len(p.local) > 0 {
+		s += "+" + p.Local()
+	}
+	return s
+}
+
+// Public - print the canonical form of the version without the local
+//  version, eg 1.0.post1 for 1.0-1+local
+func (p PEP440) Public() string {
+	s := p.BaseVersion()
+	if p.pre != "" {
+		s += p.pre + strconv.Itoa(p.preN)
+	}
+	if p.post >= 0 {
+		s += ".post" + strconv.Itoa(p.post)
+	}
+	if p.dev >= 0 {
+		s += ".dev" + strconv.Itoa(p.dev)
+	}
+	return s
+}
+
+// BaseVersion - print the canonical epoch and release of the version, eg
+//  1!2.0 for 1!2.0rc1.post3
+func (p PEP440) BaseVersion() string {
+	s := ""
+	if p.epoch != 0 {
+		s = strconv.Itoa(p.epoch) + "!"
+	}
+	strArray := make([]string, len(p.release))
+	for i, val := range p.release {
+		strArray[i] = strconv.Itoa(val)
+	}
+	return s + strings.Join(strArray, ".")
+}
+
+// Epoch - return the epoch of the version, 0 if it has none
+func (p PEP440) Epoch() int {
+	return p.epoch
+}
+
+// Release - return the release levels of the version
+func (p PEP440) Release() []int {
+	return append([]int{}, p.release...)
+}
+
+// Local - return the normalized local version, "" if there is none
+func (p PEP440) Local() string {
+	return strings.Join(p.local, ".")
+}
+
+// IsPrerelease - check if the version is a pre-release or dev release
+func (p PEP440) IsPrerelease() bool {
+	return p.pre != "" || p.dev >= 0
+}
+
+// IsPostrelease - check if the version is a post-release
+func (p PEP440) IsPostrelease() bool {
+	return p.post >= 0
+}
+
+// LessThan - check if version is less than another version
+func (p PEP440) LessThan(p2 *PEP440) bool {
+	return p.Compare(p2) == -1
+}
+
+// GreaterThan - check if version is greater than another version
+func (p PEP440) GreaterThan(p2 *PEP440) bool {
+	return p.Compare(p2) == 1
+}
+
+// Equal - check if version is equal to another version
+func (p PEP440) Equal(p2 *PEP440) bool {
+	return p.Compare(p2) == 0
+}
+
+// Compare - compare two versions, return 0 if they are equal, -1 if p < p2, or 1 if p > p2
+//  Compares the epoch, the release, the pre, post and dev releases and
+//  then the local version
+func (p PEP440) Compare(p2 *PEP440) int {
+	if c := p.compareBase(p2); c != 0 {
+		return c
+	}
+
+	pre1, pre2 := p.preKey(), p2.preKey()
+	for i := range pre1 {
+		if c := compareInt(pre1[i], pre2[i]); c != 0 {
+			return c
+		}
+	}
+	if c := compareInt(p.post, p2.post); c != 0 {
+		return c
+	}
+	if c := compareInt(p.devKey(), p2.devKey()); c != 0 {
+		return c
+	}
+	return compareLocal(p.local, p2.local)
+}
+
+// compareBase - compare the epoch and release of two versions, missing
+//  release levels are 0
+func (p PEP440) compareBase(p2 *PEP440) int {
+	if c := compareInt(p.epoch, p2.epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(p.release) || i < len(p2.release); i++ {
+		val1, val2 := 0, 0
+		if i < len(p.release) {
+			val1 = p.release[i]
+		}
+		if i < len(p2.release) {
+			val2 = p2.release[i]
+		}
+		if c := compareInt(val1, val2); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// preKey - the ordering of the pre-release. A release that is only a dev
+//  release comes before its pre-releases, 1.0.dev1 < 1.0a1, and a final
+//  release after them
+func (p PEP440) preKey() [3]int {
+	switch {
+	case p.pre == "" && p.post < 0 && p.dev >= 0:
+		return [3]int{0, 0, 0}
+	case p.pre == "":
+		return [3]int{2, 0, 0}
+	}
+	return [3]int{1, pep440PreOrder[p.pre], p.preN}
+}
+
+// devKey - the ordering of the dev release, which comes before the release
+//  without one, 1.0.post1.dev1 < 1.0.post1
+func (p PEP440) devKey() int {
+	if p.dev < 0 {
+		return int(^uint(0) >> 1)
+	}
+	return p.dev
+}
+
+// public - the version without its local version
+func (p PEP440) public() *PEP440 {
+	p.local = nil
+	return &p
+}
+
+// compareInt - compare two ints, return 0 if they are equal, -1 if a < b, or 1 if a > b
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareLocal - compare two local versions, return 0 if they are equal,
+//  -1 if l1 < l2, or 1 if l1 > l2. No local version is lowest, then part by
+//  part numbers compare as numbers and are higher than strings, which
+//  compare as strings, and if all parts are equal the longer is the higher
+func compareLocal(l1, l2 []string) int {
+	for i := 0; i < len(l1) && i < len(l2); i++ {
+		num1, num2 := isNumeric(l1[i]), isNumeric(l2[i])
+		switch {
+		case num1 && num2:
+			n1, n2 := strings.TrimLeft(l1[i], "0"), strings.TrimLeft(l2[i], "0")
+			if len(n1) != len(n2) {
+				return compareInt(len(n1), len(n2))
+			}
+			if c := strings.Compare(n1, n2); c != 0 {
+				return c
+			}
+		case num1:
+			return 1
+		case num2:
+			return -1
+		default:
+			if c := strings.Compare(l1[i], l2[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(l1), len(l2))
+}
@@ -0,0 +1,147 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPEP440Order(t *testing.T) {
+	require := require.New(t)
+
+	// each is lower than the next, from the packaging library's tests
+	ordered := []string{
+		"1.0.dev456", "1.0a1", "1.0a2.dev456", "1.0a12.dev456", "1.0a12",
+		"1.0b1.dev456", "1.0b2", "1.0b2.post345.dev456", "1.0b2.post345",
+		"1.0b2-346", "1.0c1.dev456", "1.0c1", "1.0rc2", "1.0c3", "1.0",
+		"1.0.post456.dev34", "1.0.post456", "1.1.dev1", "1.2+123abc",
+		"1.2+123abc456", "1.2+abc", "1.2+abc123", "1.2+abc123def", "1.2+1234.abc",
+		"1.2+123456", "1.2.r32+123456", "1.2.rev33+123456",
+
+		"1!1.0.dev456", "1!1.0a1", "1!1.0a2.dev456", "1!1.0a12.dev456", "1!1.0a12",
+		"1!1.0b1.dev456", "1!1.0b2", "1!1.0b2.post345.dev456", "1!1.0b2.post345",
+		"1!1.0b2-346", "1!1.0c1.dev456", "1!1.0c1", "1!1.0rc2", "1!1.0c3", "1!1.0",
+		"1!1.0.post456.dev34", "1!1.0.post456", "1!1.1.dev1", "1!1.2+123abc",
+		"1!1.2+123abc456", "1!1.2+abc", "1!1.2+abc123", "1!1.2+abc123def", "1!1.2+1234.abc",
+		"1!1.2+123456", "1!1.2.r32+123456", "1!1.2.rev33+123456",
+	}
+
+	versions := []*PEP440{}
+	for _, s := range ordered {
+		p, err := NewPEP440(s)
+		require.NoError(err, "should create the version %q", s)
+		versions = append(versions, p)
+	}
+	for i, p1 := range versions {
+		for j, p2 := range versions {
+			require.Equal(compareInt(i, j), p1.Compare(p2), "%s against %s", p1, p2)
+		}
+	}
+
+	testCases := map[string]struct {
+		v1   string
+		v2   string
+		rslt int
+	}{
+		"trailing zeros":     {v1: "1.0", v2: "1.0.0", rslt: 0},
+		"implicit epoch":     {v1: "0!1.0", v2: "1.0", rslt: 0},
+		"spelling":           {v1: "1.0-ALPHA.1", v2: "1.0a1", rslt: 0},
+		"implicit number":    {v1: "1.0a", v2: "1.0a0", rslt: 0},
+		"local separators":   {v1: "1.0+ubuntu-1", v2: "1.0+ubuntu_1", rslt: 0},
+		"local numbers":      {v1: "1.0+1.10", v2: "1.0+1.9", rslt: 1},
+		"local leading zero": {v1: "1.0+01", v2: "1.0+1", rslt: 0},
+		"epoch first":        {v1: "1!0.1", v2: "99.0", rslt: 1},
+		"release first":      {v1: "1.0.post1", v2: "1.1.dev1", rslt: -1},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			p1, _ := NewPEP440(tc.v1)
+			require.NotNil(p1, "should create the version")
+			p2, _ := NewPEP440(tc.v2)
+			require.NotNil(p2, "should create the version")
+
+			require.Equal(tc.rslt, p1.Compare(p2), "Compare should give the expected result")
+			require.Equal(tc.rslt == -1, p1.LessThan(p2), "LessThan should give the expected result")
+			require.Equal(tc.rslt == 1, p1.GreaterThan(p2), "GreaterThan should give the expected result")
+			require.Equal(tc.rslt == 0, p1.Equal(p2), "Equal should give the expected result")
+		})
+	}
+}
+
+func TestPEP440Normalized(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]string{
+		"1.0":                 "1.0",
+		"v1.0":                "1.0",
+		" 1.0\n":              "1.0",
+		"01.02":               "1.2",
+		"0!1.0":               "1.0",
+		"1!1.0":               "1!1.0",
+		"1.0a":                "1.0a0",
+		"1.0.alpha1":          "1.0a1",
+		"1.0-beta.2":          "1.0b2",
+		"1.0B2":               "1.0b2",
+		"1.0c1":               "1.0rc1",
+		"1.0pre1":             "1.0rc1",
+		"1.0preview1":         "1.0rc1",
+		"1.0-1":               "1.0.post1",
+		"1.0.rev1":            "1.0.post1",
+		"1.0r":                "1.0.post0",
+		"1.0.POST":            "1.0.post0",
+		"1.0_post_2":          "1.0.post2",
+		"1.0-dev":             "1.0.dev0",
+		"1.0dev4":             "1.0.dev4",
+		"1.0+ubuntu-1":        "1.0+ubuntu.1",
+		"1.0+Local_A.7":       "1.0+local.a.7",
+		"1.0.dev4+local.7":    "1.0.dev4+local.7",
+		"2.0a3":               "2.0a3",
+		"1.0rc1.post2.dev3+a": "1.0rc1.post2.dev3+a",
+	}
+
+	for s, want := range testCases {
+		p, err := NewPEP440(s)
+		require.NoError(err, "should create the version %q", s)
+		require.Equal(s, p.String(), "should keep the version as given")
+		require.Equal(want, p.Normalized(), "%q should normalize", s)
+	}
+
+	p, _ := NewPEP440("1!2.0rc1.post3+local.7")
+	require.Equal(1, p.Epoch(), "should have the epoch")
+	require.Equal([]int{2, 0}, p.Release(), "should have the release")
+	require.Equal("local.7", p.Local(), "should have the local version")
+	require.Equal("1!2.0rc1.post3", p.Public(), "should have the public version")
+	require.Equal("1!2.0", p.BaseVersion(), "should have the base version")
+	require.True(p.IsPrerelease(), "should be a pre-release")
+	require.True(p.IsPostrelease(), "should be a post-release")
+
+	p, _ = NewPEP440("1.0.dev1")
+	require.True(p.IsPrerelease(), "a dev release is a pre-release")
+	require.False(p.IsPostrelease(), "should not be a post-release")
+}
+
+func TestNewPEP440Errors(t *testing.T) {
+	require := require.New(t)
+
+	for _, s := range []string{
+		"french toast",
+		"1.0+a+",
+		"1.0++",
+		"1.0+_foobar",
+		"1.0+foo&asd",
+		"1.0+1+1",
+		"1.0-",
+		"1!",
+		"1.0+",
+		"1..0",
+		"1.0a1a1",
+		"99999999999999999999.0",
+	} {
+		p, err := NewPEP440(s)
+		require.Nil(p, "%q should not return a version", s)
+		require.ErrorIs(err, InvalidPEP440, "%q should return the expected error", s)
+	}
+	_, err := NewPEP440("")
+	require.Equal(InvalidVersion, err, "should reject an empty string")
+}
@@ -0,0 +1,128 @@
+package version
+
+import (
+	"fmt"
+)
+
+var InvalidPolicy = fmt.Errorf("Invalid comparison policy")
+
+// Policy - how versions with different numbers of levels compare
+type Policy int
+
+const (
+	// Strict - the shorter version is the lower when the levels they share
+	//  are equal, ie 1.1.1 < 1.1.1.0
+	Strict Policy = iota
+	// ZeroPadded - missing levels count as 0, ie 1.1.1 == 1.1.1.0
+	ZeroPadded
+	// Significant - only the first N levels count, missing ones as 0, and
+	//  anything after them, including a pre-release, is ignored.
+	//  With 2 levels 1.2 == 1.2.0 == 1.2.7 < 1.3.0
+	Significant
+)
+
+// policyNames - the name of each policy
+var policyNames = []string{
+	"strict",
+	"zero-padded",
+	"significant",
+}
+
+// String - print the policy name
+func (p Policy) String() string {
+	if p < 0 || int(p) >= len(policyNames) {
+		return fmt.Sprintf("Policy(%d)", int(p))
+	}
+	return policyNames[p]
+}
+
+// LeadingZeros - how NewVersion treats a level with leading zeros, such as 01
+type LeadingZeros int
+
+const (
+	// IgnoreLeadingZeros - a level is its number, ie 1.01 == 1.1
+	IgnoreLeadingZeros LeadingZeros = iota
+	// RejectLeadingZeros - a level with leading zeros is an InvalidElement,
+	//  as in SemVer
+	RejectLeadingZeros
+)
+
+// Option - an option for NewVersion and NewSemVer
+type Option func(*Version)
+
+// WithPolicy - compare the version using the given policy, Strict if not set
+func WithPolicy(p Policy) Option {
+	return func(v *Version) {
+		v.policy = p
+	}
+}
+
+// WithSignificantLevels - compare only the first n levels of the version,
+//  see Significant
+func WithSignificantLevels(n int) Option {
+	return func(v *Version) {
+		v.policy = Significant
+		v.levels = n
+	}
+}
+
+// WithLeadingZeros - how levels with leading zeros are parsed,
+//  IgnoreLeadingZeros if not set. SemVer always rejects them
+func WithLeadingZeros(z LeadingZeros) Option {
+	return func(v *Version) {
+		v.zeros = z
+	}
+}
+
+// Comparator - compares versions using one policy, whatever the policies
+//  they were created with, so that versions with different policies can be
+//  sorted together. Version.Compare uses the policy of the version it is
+//  called on, so a.Compare(b) and b.Compare(a) can disagree when their
+//  policies differ, eg
+//    cmp, err := NewComparator(WithPolicy(ZeroPadded))
+//    c.SortWith(cmp)
+type Comparator struct {
+	policy Policy
+	levels int
+}
+
+// NewComparator - create a Comparator from the policy options, WithPolicy
+//  or WithSignificantLevels, Strict if not set.
+//  Returns InvalidPolicy for the same options NewVersion would
+func NewComparator(opts ...Option) (*Comparator, error) {
+	v := &Version{}
+	if err := applyOptions(v, opts); err != nil {
+		return nil, err
+	}
+	return &Comparator{policy: v.policy, levels: v.levels}, nil
+}
+
+// Compare - compare two versions using the policy of the Comparator, return
+//  0 if they are equal, -1 if v1 < v2, or 1 if v1 > v2, see Version.Compare
+func (cmp Comparator) Compare(v1, v2 *Version) int {
+	return v1.compareAs(v2, cmp.policy, cmp.levels)
+}
+
+// options - the option giving a new version the same options as v
+func (v Version) options() Option {
+	return func(o *Version) {
+		o.policy, o.levels, o.zeros = v.policy, v.levels, v.zeros
+	}
+}
+
+// applyOptions - apply the options to a new version, checking the policy
+//  they leave it with is usable
+func applyOptions(v *Version, opts []Option) error {
+	for _, opt := range opts {
+		opt(v)
+	}
+	switch {
+	case v.policy < Strict || v.policy > Significant:
+		return fmt.Errorf("%w: %v", InvalidPolicy, v.policy)
+	case v.policy == Significant && v.levels < 1:
+		return fmt.Errorf("%w: %v needs at least 1 level, got %d", InvalidPolicy, v.policy, v.levels)
+	case v.zeros < IgnoreLeadingZeros || v.zeros > RejectLeadingZeros:
+		return fmt.Errorf("%w: unknown leading zeros option %d", InvalidPolicy, int(v.zeros))
+	}
+	return nil
+}
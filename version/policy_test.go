@@ -0,0 +1,215 @@
+package version
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyCompare(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		opts []Option
+		v1   string
+		v2   string
+		rslt int
+	}{
+		"strict, longer": {
+			opts: []Option{WithPolicy(Strict)},
+			v1:   "1.1.1.0",
+			v2:   "1.1.1",
+			rslt: 1,
+		},
+		"zero padded, longer": {
+			opts: []Option{WithPolicy(ZeroPadded)},
+			v1:   "1.1.1.0",
+			v2:   "1.1.1",
+			rslt: 0,
+		},
+		"zero padded, shorter": {
+			opts: []Option{WithPolicy(ZeroPadded)},
+			v1:   "1",
+			v2:   "1.0.0.0",
+			rslt: 0,
+		},
+		"zero padded, non-zero extra level": {
+			opts: []Option{WithPolicy(ZeroPadded)},
+			v1:   "1.1.1",
+			v2:   "1.1.1.1",
+			rslt: -1,
+		},
+		"zero padded, lower level decides": {
+			opts: []Option{WithPolicy(ZeroPadded)},
+			v1:   "1.2",
+			v2:   "1.1.9.9",
+			rslt: 1,
+		},
+		"significant, ignored levels": {
+			opts: []Option{WithSignificantLevels(2)},
+			v1:   "1.2.7",
+			v2:   "1.2.0.5",
+			rslt: 0,
+		},
+		"significant, padded": {
+			opts: []Option{WithSignificantLevels(3)},
+			v1:   "1.2",
+			v2:   "1.2.0.5",
+			rslt: 0,
+		},
+		"significant, compared level": {
+			opts: []Option{WithSignificantLevels(2)},
+			v1:   "1.2.7",
+			v2:   "1.3",
+			rslt: -1,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ver1, _ := NewVersion(tc.v1, tc.opts...)
+			require.NotNil(ver1, "should create Version")
+			ver2, _ := NewVersion(tc.v2, tc.opts...)
+			require.NotNil(ver2, "should create Version")
+
+			require.Equal(tc.rslt, ver1.Compare(ver2), "Compare should give the expected result")
+			require.Equal(-tc.rslt, ver2.Compare(ver1), "Compare should be symmetric")
+			require.Equal(tc.rslt == -1, ver1.LessThan(ver2), "LessThan should give the expected result")
+			require.Equal(tc.rslt == 1, ver1.GreaterThan(ver2), "GreaterThan should give the expected result")
+			require.Equal(tc.rslt == 0, ver1.Equal(ver2), "Equal should give the expected result")
+		})
+	}
+}
+
+func TestPolicySemVer(t *testing.T) {
+	require := require.New(t)
+
+	rc, _ := NewSemVer("1.2.3-rc.1", WithPolicy(ZeroPadded))
+	release, _ := NewSemVer("1.2.3", WithPolicy(ZeroPadded))
+	require.True(rc.LessThan(release), "zero padded should still order pre-releases")
+
+	rc, _ = NewSemVer("1.2.3-rc.1", WithSignificantLevels(2))
+	release, _ = NewSemVer("1.2.4", WithSignificantLevels(2))
+	require.True(rc.Equal(release), "significant levels should ignore the rest")
+}
+
+func TestPolicyCollection(t *testing.T) {
+	require := require.New(t)
+
+	c := Collection{}
+	for _, s := range []string{"1.1.1.0", "1.2", "1.1.1", "1.1", "1.2.0"} {
+		v, _ := NewVersion(s, WithPolicy(ZeroPadded))
+		c = append(c, v)
+	}
+
+	sort.Stable(c)
+	require.Equal([]string{"1.1", "1.1.1.0", "1.1.1", "1.2", "1.2.0"}, stringsOf(c), "should sort equal versions together")
+	require.Equal([]string{"1.1", "1.1.1.0", "1.2"}, stringsOf(c.Dedup()), "should dedup padded versions")
+	require.Equal("1.2", c.Latest().String(), "should get the first latest")
+}
+
+func TestPolicyErrors(t *testing.T) {
+	require := require.New(t)
+
+	v, err := NewVersion("1.2", WithSignificantLevels(0))
+	require.Nil(v, "a version should not be returned")
+	require.ErrorIs(err, InvalidPolicy, "should reject no significant levels")
+
+	v, err = NewVersion("1.2", WithPolicy(Significant))
+	require.Nil(v, "a version should not be returned")
+	require.ErrorIs(err, InvalidPolicy, "should need significant levels")
+
+	v, err = NewSemVer("1.2.3", WithPolicy(Policy(7)))
+	require.Nil(v, "a version should not be returned")
+	require.ErrorIs(err, InvalidPolicy, "should reject an unknown policy")
+	require.Equal("Policy(7)", Policy(7).String(), "should print an unknown policy")
+	require.Equal("zero-padded", ZeroPadded.String(), "should print the policy name")
+
+	v, err = NewVersion("1.2", WithLeadingZeros(LeadingZeros(3)))
+	require.Nil(v, "a version should not be returned")
+	require.ErrorIs(err, InvalidPolicy, "should reject an unknown leading zeros option")
+}
+
+func TestLeadingZeros(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		opts []Option
+		ver  string
+		err  error
+	}{
+		"ignored by default":        {ver: "1.02.003"},
+		"ignored":                   {opts: []Option{WithLeadingZeros(IgnoreLeadingZeros)}, ver: "1.02.003"},
+		"rejected":                  {opts: []Option{WithLeadingZeros(RejectLeadingZeros)}, ver: "1.02.3", err: InvalidElement},
+		"rejected, long level":      {opts: []Option{WithLeadingZeros(RejectLeadingZeros)}, ver: "1.000000000000000000000001", err: InvalidElement},
+		"rejected, zero is fine":    {opts: []Option{WithLeadingZeros(RejectLeadingZeros)}, ver: "1.0.0"},
+		"rejected, with a policy":   {opts: []Option{WithPolicy(ZeroPadded), WithLeadingZeros(RejectLeadingZeros)}, ver: "1.00", err: InvalidElement},
+		"rejected, long level fine": {opts: []Option{WithLeadingZeros(RejectLeadingZeros)}, ver: "1.100000000000000000000001"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			v, err := NewVersion(tc.ver, tc.opts...)
+			require.ErrorIs(err, tc.err)
+			if tc.err != nil {
+				require.Nil(v, "a version should not be returned")
+				return
+			}
+			require.Equal(tc.ver, v.String(), "should keep the version string")
+		})
+	}
+
+	v1, _ := NewVersion("1.02.003")
+	v2, _ := NewVersion("1.2.3")
+	require.True(v1.Equal(v2), "ignored leading zeros should not change the order")
+	require.Equal(v2.SortKey(), v1.SortKey(), "ignored leading zeros should not change the sort key")
+
+	v, _ := NewVersion("1", WithLeadingZeros(RejectLeadingZeros))
+	require.NoError(v.UnmarshalText([]byte("1.2")))
+	require.ErrorIs(v.UnmarshalText([]byte("1.02")), InvalidElement, "unmarshal should keep the option")
+}
+
+func TestComparator(t *testing.T) {
+	require := require.New(t)
+
+	// versions with mixed policies, which Version.Compare cannot order
+	c := Collection{}
+	for i, s := range []string{"1.1.0.0", "1.2", "1.1", "1.1.0", "1.0.9"} {
+		policy := Strict
+		if i%2 == 1 {
+			policy = ZeroPadded
+		}
+		v, _ := NewVersion(s, WithPolicy(policy))
+		c = append(c, v)
+	}
+
+	testCases := map[string]struct {
+		opts []Option
+		want []string
+	}{
+		"strict":      {opts: []Option{WithPolicy(Strict)}, want: []string{"1.0.9", "1.1", "1.1.0", "1.1.0.0", "1.2"}},
+		"zero padded": {opts: []Option{WithPolicy(ZeroPadded)}, want: []string{"1.0.9", "1.1.0.0", "1.1", "1.1.0", "1.2"}},
+		"significant": {opts: []Option{WithSignificantLevels(1)}, want: []string{"1.1.0.0", "1.2", "1.1", "1.1.0", "1.0.9"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cmp, err := NewComparator(tc.opts...)
+			require.NoError(err)
+			for _, v1 := range c {
+				for _, v2 := range c {
+					require.Equal(-cmp.Compare(v1, v2), cmp.Compare(v2, v1), "%v and %v should compare symmetrically", v1, v2)
+				}
+			}
+
+			sorted := append(Collection{}, c...)
+			sorted.SortWith(cmp)
+			require.Equal(tc.want, stringsOf(sorted), "should sort by the comparator policy")
+		})
+	}
+
+	cmp, err := NewComparator(WithPolicy(Significant))
+	require.Nil(cmp, "a comparator should not be returned")
+	require.ErrorIs(err, InvalidPolicy, "should need significant levels")
+}
@@ -0,0 +1,294 @@
+package version
+
+import (
+	"sort"
+	"strings"
+)
+
+// VersionRange - the versions between a lower and an upper bound, each of
+//  which can include or exclude its version, or be missing for a range with
+//  no lower or upper limit
+type VersionRange struct {
+	lower, upper       *Version // nil if unbounded
+	lowerInc, upperInc bool
+}
+
+// RangeSet - a set of versions, as the ranges in it, eg ">=1.2 <3 || >=4".
+//  A RangeSet can be intersected, joined and complemented, and is always kept
+//  in its canonical form: ranges in order, without overlaps or empty ranges.
+//  Versions are compared with Version.Compare, so they should all have the
+//  same policy. Ranges are taken to be dense, as there is a version between
+//  any two others, eg 1.2 < 1.2.0-rc < 1.2.0, so only a range whose bounds
+//  are out of order, or the same version not included at both ends, is empty
+type RangeSet struct {
+	ranges []VersionRange
+}
+
+// NewRangeSet - create a RangeSet from a constraint string, see Constraint
+func NewRangeSet(s string) (*RangeSet, error) {
+	c, err := NewConstraint(s)
+	if err != nil {
+		return nil, err
+	}
+	return c.RangeSet(), nil
+}
+
+// RangeSet - the set of versions that satisfy the constraint
+func (c Constraint) RangeSet() *RangeSet {
+	set := &RangeSet{}
+	for _, group := range c.groups {
+		groupSet := &RangeSet{ranges: []VersionRange{{}}}
+		for _, r := range group {
+			groupSet = groupSet.Intersect(r.ranges())
+		}
+		set = set.Union(groupSet)
+	}
+	return set
+}
+
+// ranges - the set of versions that satisfy the rule
+func (r rule) ranges() *RangeSet {
+	var ranges []VersionRange
+	switch r.op {
+	case "=":
+		ranges = []VersionRange{{lower: r.ver, lowerInc: true, upper: r.ver, upperInc: true}}
+	case "!=":
+		ranges = []VersionRange{{upper: r.ver}, {lower: r.ver}}
+	case ">":
+		ranges = []VersionRange{{lower: r.ver}}
+	case ">=":
+		ranges = []VersionRange{{lower: r.ver, lowerInc: true}}
+	case "<":
+		ranges = []VersionRange{{upper: r.ver}}
+	case "<=":
+		ranges = []VersionRange{{upper: r.ver, upperInc: true}}
+	}
+	return newRangeSet(ranges)
+}
+
+// newRangeSet - a RangeSet of the ranges, in canonical form
+func newRangeSet(ranges []VersionRange) *RangeSet {
+	sorted := []VersionRange{}
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compareLower(sorted[i], sorted[j]) < 0
+	})
+
+	// merge ranges that overlap, or meet at a version one of them includes
+	merged := []VersionRange{}
+	for _, r := range sorted {
+		if len(merged) > 0 && touches(merged[len(merged)-1], r) {
+			last := &merged[len(merged)-1]
+			if compareUpper(r, *last) > 0 {
+				last.upper, last.upperInc = r.upper, r.upperInc
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return &RangeSet{ranges: merged}
+}
+
+// Ranges - the ranges in the set, in order
+func (rs RangeSet) Ranges() []VersionRange {
+	return append([]VersionRange{}, rs.ranges...)
+}
+
+// String - print the set in canonical form, as a constraint, eg ">=1.2 <3 || 4.1.0".
+//  Every version is "*", and no version is ""
+func (rs RangeSet) String() string {
+	strs := make([]string, len(rs.ranges))
+	for i, r := range rs.ranges {
+		strs[i] = r.String()
+	}
+	return strings.Join(strs, " || ")
+}
+
+// IsEmpty - check if the set has no versions
+func (rs RangeSet) IsEmpty() bool {
+	return len(rs.ranges) == 0
+}
+
+// Contains - check if the version is in the set
+func (rs RangeSet) Contains(v *Version) bool {
+	for _, r := range rs.ranges {
+		if r.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersect - the versions in both sets
+func (rs RangeSet) Intersect(rs2 *RangeSet) *RangeSet {
+	ranges := []VersionRange{}
+	for _, r1 := range rs.ranges {
+		for _, r2 := range rs2.ranges {
+			ranges = append(ranges, r1.Intersect(r2))
+		}
+	}
+	return newRangeSet(ranges)
+}
+
+// Union - the versions in either set
+func (rs RangeSet) Union(rs2 *RangeSet) *RangeSet {
+	return newRangeSet(append(rs.Ranges(), rs2.ranges...))
+}
+
+// Complement - the versions not in the set
+func (rs RangeSet) Complement() *RangeSet {
+	gaps := []VersionRange{}
+	gap := VersionRange{}
+	for _, r := range rs.ranges {
+		gap.upper, gap.upperInc = r.lower, !r.lowerInc
+		if r.lower != nil {
+			gaps = append(gaps, gap)
+		}
+		gap = VersionRange{lower: r.upper, lowerInc: !r.upperInc}
+	}
+	if len(rs.ranges) == 0 || gap.lower != nil {
+		gaps = append(gaps, gap)
+	}
+	return newRangeSet(gaps)
+}
+
+// Lower - the lower bound of the range, and whether it includes it, nil if
+//  there is no lower bound
+func (r VersionRange) Lower() (*Version, bool) {
+	return r.lower, r.lowerInc
+}
+
+// Upper - the upper bound of the range, and whether it includes it, nil if
+//  there is no upper bound
+func (r VersionRange) Upper() (*Version, bool) {
+	return r.upper, r.upperInc
+}
+
+// String - print the range as a constraint, eg ">=1.2 <3", "1.2.3" for a
+//  single version, or "*" for every version
+func (r VersionRange) String() string {
+	if r.lower != nil && r.upper != nil && r.lower.Equal(r.upper) {
+		return r.lower.String()
+	}
+
+	strs := []string{}
+	if r.lower != nil {
+		op := ">"
+		if r.lowerInc {
+			op = ">="
+		}
+		strs = append(strs, op+r.lower.String())
+	}
+	if r.upper != nil {
+		op := "<"
+		if r.upperInc {
+			op = "<="
+		}
+		strs = append(strs, op+r.upper.String())
+	}
+	if len(strs) == 0 {
+		return "*"
+	}
+	return strings.Join(strs, " ")
+}
+
+// IsEmpty - check if the range has no versions, its bounds are out of
+//  order, or the same version not included at both ends
+func (r VersionRange) IsEmpty() bool {
+	if r.lower == nil || r.upper == nil {
+		return false
+	}
+	cmp := r.lower.Compare(r.upper)
+	return cmp > 0 || (cmp == 0 && !(r.lowerInc && r.upperInc))
+}
+
+// Contains - check if the version is in the range
+func (r VersionRange) Contains(v *Version) bool {
+	if r.lower != nil {
+		cmp := v.Compare(r.lower)
+		if cmp < 0 || (cmp == 0 && !r.lowerInc) {
+			return false
+		}
+	}
+	if r.upper != nil {
+		cmp := v.Compare(r.upper)
+		if cmp > 0 || (cmp == 0 && !r.upperInc) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect - the versions in both ranges, which may be empty
+func (r VersionRange) Intersect(r2 VersionRange) VersionRange {
+	result := r
+	if compareLower(r2, r) > 0 {
+		result.lower, result.lowerInc = r2.lower, r2.lowerInc
+	}
+	if compareUpper(r2, r) < 0 {
+		result.upper, result.upperInc = r2.upper, r2.upperInc
+	}
+	return result
+}
+
+// compareLower - compare the lower bounds of two ranges, return -1 if r1
+//  starts first, 0 if they start together, or 1 if r2 starts first
+func compareLower(r1, r2 VersionRange) int {
+	switch {
+	case r1.lower == nil && r2.lower == nil:
+		return 0
+	case r1.lower == nil:
+		return -1
+	case r2.lower == nil:
+		return 1
+	}
+	if cmp := r1.lower.Compare(r2.lower); cmp != 0 {
+		return cmp
+	}
+	// including the version starts before excluding it
+	switch {
+	case r1.lowerInc == r2.lowerInc:
+		return 0
+	case r1.lowerInc:
+		return -1
+	}
+	return 1
+}
+
+// compareUpper - compare the upper bounds of two ranges, return -1 if r1
+//  ends first, 0 if they end together, or 1 if r2 ends first
+func compareUpper(r1, r2 VersionRange) int {
+	switch {
+	case r1.upper == nil && r2.upper == nil:
+		return 0
+	case r1.upper == nil:
+		return 1
+	case r2.upper == nil:
+		return -1
+	}
+	if cmp := r1.upper.Compare(r2.upper); cmp != 0 {
+		return cmp
+	}
+	// excluding the version ends before including it
+	switch {
+	case r1.upperInc == r2.upperInc:
+		return 0
+	case r1.upperInc:
+		return 1
+	}
+	return -1
+}
+
+// touches - check if r2, which does not start before r1, overlaps r1 or
+//  meets it at a version one of them includes, so they can be merged
+func touches(r1, r2 VersionRange) bool {
+	if r1.upper == nil || r2.lower == nil {
+		return true
+	}
+	cmp := r2.lower.Compare(r1.upper)
+	return cmp < 0 || (cmp == 0 && (r1.upperInc || r2.lowerInc))
+}
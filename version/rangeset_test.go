@@ -0,0 +1,189 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rangeSetOf - parse a RangeSet, "" for an empty one
+func rangeSetOf(require *require.Assertions, s string) *RangeSet {
+	if s == "" {
+		return &RangeSet{}
+	}
+	rs, err := NewRangeSet(s)
+	require.NoError(err, "%q should parse", s)
+	return rs
+}
+
+func TestRangeSetString(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		constraint string
+		want       string
+	}{
+		"range":               {constraint: ">=1.2 <3", want: ">=1.2 <3"},
+		"comma":               {constraint: ">= 1.2, < 3", want: ">=1.2 <3"},
+		"caret":               {constraint: "^2.1", want: ">=2.1 <3"},
+		"caret zero":          {constraint: "^0.2.3", want: ">=0.2.3 <0.3"},
+		"tilde":               {constraint: "~1.2.3", want: ">=1.2.3 <1.3"},
+		"wildcard":            {constraint: "1.x", want: ">=1 <2"},
+		"every version":       {constraint: "*", want: "*"},
+		"single version":      {constraint: "=1.2.3", want: "1.2.3"},
+		"not equal":           {constraint: "!=1.5", want: "<1.5 || >1.5"},
+		"overlapping":         {constraint: ">=1 <2 || >=1.5 <3", want: ">=1 <3"},
+		"adjacent":            {constraint: ">=1 <2 || >=2 <3", want: ">=1 <3"},
+		"gap of one version":  {constraint: "<1 || >1", want: "<1 || >1"},
+		"joined by a version": {constraint: "<1 || 1 || >1", want: "*"},
+		"complementary":       {constraint: "<=1 || >1", want: "*"},
+		"out of order":        {constraint: ">=3 || <1", want: "<1 || >=3"},
+		"duplicate":           {constraint: "1.2.3 || 1.2.3", want: "1.2.3"},
+		"contained":           {constraint: ">=1 <5 || >=2 <3", want: ">=1 <5"},
+		"empty":               {constraint: ">=2 <1", want: ""},
+		"empty at a version":  {constraint: ">1 <1", want: ""},
+		"same bound":          {constraint: ">=1 <=1", want: "1"},
+		"pre-release":         {constraint: ">=2.0.0-rc.1 <2.0.0", want: ">=2.0.0-rc.1 <2.0.0"},
+		"four levels":         {constraint: "~4.1.0.7 || 4.3.0.0", want: ">=4.1.0.7 <4.2 || 4.3.0.0"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rs := rangeSetOf(require, tc.constraint)
+			require.Equal(tc.want, rs.String())
+			require.Equal(tc.want == "", rs.IsEmpty())
+			if tc.want != "" {
+				require.Equal(tc.want, rangeSetOf(require, rs.String()).String(), "should parse its own string")
+			}
+		})
+	}
+
+	_, err := NewRangeSet(">=1 ||")
+	require.ErrorIs(err, InvalidConstraint)
+}
+
+func TestRangeSetOperations(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		rs1, rs2   string
+		intersect  string
+		union      string
+		complement string
+	}{
+		"overlapping": {
+			rs1: ">=1.2 <3", rs2: "^2.1",
+			intersect: ">=2.1 <3", union: ">=1.2 <3", complement: "<1.2 || >=3",
+		},
+		"disjoint": {
+			rs1: "^1", rs2: "^2",
+			intersect: "", union: ">=1 <3", complement: "<1 || >=2",
+		},
+		"disjoint with a gap": {
+			rs1: "^1", rs2: "^3",
+			intersect: "", union: ">=1 <2 || >=3 <4", complement: "<1 || >=2",
+		},
+		"multiple ranges": {
+			rs1: "<2 || >=3", rs2: ">=1.5 <3.5",
+			intersect: ">=1.5 <2 || >=3 <3.5", union: "*", complement: ">=2 <3",
+		},
+		"meeting at a version": {
+			rs1: "<=2", rs2: ">=2",
+			intersect: "2", union: "*", complement: ">2",
+		},
+		"meeting at an excluded version": {
+			rs1: "<2", rs2: ">2",
+			intersect: "", union: "<2 || >2", complement: ">=2",
+		},
+		"every version": {
+			rs1: "*", rs2: "!=1.0.0",
+			intersect: "<1.0.0 || >1.0.0", union: "*", complement: "",
+		},
+		"no version": {
+			rs1: "", rs2: "^1.2",
+			intersect: "", union: ">=1.2 <2", complement: "*",
+		},
+		"single version": {
+			rs1: "1.0.0", rs2: "1.0.0 || 2.0.0",
+			intersect: "1.0.0", union: "1.0.0 || 2.0.0", complement: "<1.0.0 || >1.0.0",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rs1, rs2 := rangeSetOf(require, tc.rs1), rangeSetOf(require, tc.rs2)
+			before := rs1.String()
+			require.Equal(tc.intersect, rs1.Intersect(rs2).String(), "intersect")
+			require.Equal(tc.intersect, rs2.Intersect(rs1).String(), "intersect the other way")
+			require.Equal(tc.union, rs1.Union(rs2).String(), "union")
+			require.Equal(tc.union, rs2.Union(rs1).String(), "union the other way")
+			require.Equal(tc.complement, rs1.Complement().String(), "complement")
+			require.Equal(rs1.String(), rs1.Complement().Complement().String(), "complement twice")
+			require.Equal(before, rs1.String(), "operations should not change the set")
+		})
+	}
+}
+
+func TestRangeSetContains(t *testing.T) {
+	require := require.New(t)
+
+	constraints := []string{
+		"*", "^1.2", "~1.2.3", ">=1.2 <3", "!=2.0.0", "<1 || >=2.1", "1.x || 3.x",
+		"<=1.2.3", ">1.2.3", "1.2.3", ">=2.0.0-rc.1", ">=1 <1", "<2 || >2",
+	}
+	versions := []string{
+		"0", "0.9", "1", "1.0", "1.2", "1.2.0", "1.2.3", "1.2.4", "1.3", "1.10", "2",
+		"2.0.0-rc.1", "2.0.0-rc.2", "2.0.0", "2.0.1", "2.1", "2.5.5.5", "3", "3.0.1", "4",
+	}
+
+	sets := []*RangeSet{}
+	for _, c := range constraints {
+		sets = append(sets, rangeSetOf(require, c))
+	}
+
+	for _, s := range versions {
+		v, err := NewSemVer(s)
+		if err != nil {
+			v, err = NewVersion(s)
+		}
+		require.NoError(err)
+
+		for i, rs1 := range sets {
+			c, err := NewConstraint(constraints[i])
+			require.NoError(err)
+			in1 := rs1.Contains(v)
+			require.Equal(c.Check(v), in1, "%s in %s should match the constraint", v, constraints[i])
+			require.Equal(!in1, rs1.Complement().Contains(v), "%s in the complement of %s", v, rs1)
+
+			for j, rs2 := range sets {
+				in2 := rs2.Contains(v)
+				require.Equal(in1 && in2, rs1.Intersect(rs2).Contains(v),
+					"%s in %s intersect %s", v, constraints[i], constraints[j])
+				require.Equal(in1 || in2, rs1.Union(rs2).Contains(v),
+					"%s in %s union %s", v, constraints[i], constraints[j])
+			}
+		}
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	require := require.New(t)
+
+	rs := rangeSetOf(require, ">=1.2 <3 || 4")
+	ranges := rs.Ranges()
+	require.Len(ranges, 2)
+
+	lower, inc := ranges[0].Lower()
+	require.Equal("1.2", lower.String())
+	require.True(inc)
+	upper, inc := ranges[0].Upper()
+	require.Equal("3", upper.String())
+	require.False(inc)
+	require.Equal(">=1.2 <3", ranges[0].String())
+
+	both := ranges[0].Intersect(ranges[1])
+	require.True(both.IsEmpty(), "disjoint ranges should have an empty intersection")
+
+	lower, _ = rangeSetOf(require, "<3").Ranges()[0].Lower()
+	require.Nil(lower, "should have no lower bound")
+}
@@ -0,0 +1,912 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	InvalidPackage = fmt.Errorf("Invalid package")
+	NoSolution     = fmt.Errorf("No compatible set of versions")
+)
+
+// Resolver - picks a version of each package needed by a set of requirements,
+//  so that every dependency of every picked version is met.
+//  It uses the PubGrub algorithm, so when there is no solution the error
+//  explains why, step by step, and it is deterministic: the same packages
+//  and requirements always give the same result.
+//  Newer versions are tried first, so the result is the newest compatible
+//  set, preferring newer versions of packages with fewer versions to pick from
+type Resolver struct {
+	packages map[string][]*release // newest first
+}
+
+// release - one version of a package, and its dependencies
+type release struct {
+	ver  *Version
+	deps map[string]*Constraint
+}
+
+// NewResolver - create a Resolver with no packages
+func NewResolver() *Resolver {
+	return &Resolver{packages: map[string][]*release{}}
+}
+
+// Add - add a version of a package, and the constraints it has on the
+//  versions of the packages it depends on, eg
+//    r.Add("api", "2.1.0", map[string]string{"db": "^1.4", "auth": ">=3"})
+//  The version is parsed as SemVer if it has a pre-release or build metadata,
+//  otherwise by NewVersion.
+//  Returns InvalidPackage if the version or a constraint cannot be parsed, or
+//  the version has already been added
+func (r *Resolver) Add(name, ver string, deps map[string]string) error {
+	if name == "" {
+		return fmt.Errorf("%w: a package must have a name", InvalidPackage)
+	}
+	v, err := parseConstraintVersion(ver)
+	if err != nil {
+		return fmt.Errorf("%w: %s %q: %v", InvalidPackage, name, ver, err)
+	}
+	for _, rel := range r.packages[name] {
+		if rel.ver.Equal(v) {
+			return fmt.Errorf("%w: %s %s has already been added", InvalidPackage, name, ver)
+		}
+	}
+
+	rel := &release{ver: v, deps: map[string]*Constraint{}}
+	for dep, constraint := range deps {
+		if dep == "" || dep == name {
+			return fmt.Errorf("%w: %s %s cannot depend on %q", InvalidPackage, name, ver, dep)
+		}
+		c, err := NewConstraint(constraint)
+		if err != nil {
+			return fmt.Errorf("%w: %s %s dependency %s: %v", InvalidPackage, name, ver, dep, err)
+		}
+		rel.deps[dep] = c
+	}
+
+	r.packages[name] = append(r.packages[name], rel)
+	sort.SliceStable(r.packages[name], func(i, j int) bool {
+		return r.packages[name][i].ver.GreaterThan(r.packages[name][j].ver)
+	})
+	return nil
+}
+
+// Resolve - pick the newest version of each package needed to meet the
+//  requirements, constraints by package name, and of each package they
+//  depend on, in turn.
+//  Returns NoSolution, with an explanation of the conflict, if no versions
+//  meet the requirements, or InvalidPackage if a requirement cannot be parsed
+func (r *Resolver) Resolve(requirements map[string]string) (map[string]*Version, error) {
+	root := &release{ver: &Version{}, deps: map[string]*Constraint{}}
+	for name, constraint := range requirements {
+		c, err := NewConstraint(constraint)
+		if err != nil {
+			return nil, fmt.Errorf("%w: requirement %s: %v", InvalidPackage, name, err)
+		}
+		root.deps[name] = c
+	}
+
+	s := &solver{
+		packages:  map[string][]*release{rootPackage: {root}},
+		incompats: map[string][]*incompat{},
+		decisions: map[string]int{},
+	}
+	for name, releases := range r.packages {
+		s.packages[name] = releases
+	}
+	return s.solve()
+}
+
+// rootPackage - the package name standing for the requirements, which Add
+//  does not allow
+const rootPackage = ""
+
+// solver - the state of one Resolve, see
+//  https://github.com/dart-lang/pub/blob/master/doc/solver.md
+type solver struct {
+	packages    map[string][]*release
+	incompats   map[string][]*incompat // by the packages in them
+	assignments []*assignment          // the partial solution
+	decisions   map[string]int         // the index of the release picked, by package
+}
+
+// versionSet - a set of the versions of one package, by their index in
+//  solver.packages. The extra last index stands for versions that do not
+//  exist, in the set of a constraint no version matches, so that depending
+//  on it is not the same as depending on nothing
+type versionSet []bool
+
+// term - a statement about a package, that its version is in the set, or
+//  for a negative term that it is not, which includes not picking it at all
+type term struct {
+	pkg  string
+	pos  bool
+	set  versionSet
+	desc string // how the set was written, "" to describe it from its versions
+}
+
+// incompat cause kinds
+const (
+	causeRoot = iota
+	causeDependency
+	causeNoVersions
+	causeConflict
+)
+
+// incompat - terms that cannot all be true, and why
+type incompat struct {
+	terms []term
+	cause int
+	from  [2]*incompat // the incompats a causeConflict one is derived from
+}
+
+// assignment - a term the solver has decided, if cause is nil, or derived
+//  from cause
+type assignment struct {
+	term
+	level int
+	cause *incompat
+}
+
+// term relations to the partial solution
+const (
+	relSatisfied = iota
+	relContradicted
+	relInconclusive
+)
+
+// solve - run PubGrub, starting with the root package, until every package
+//  needed has a version or there is a conflict that cannot be resolved
+func (s *solver) solve() (map[string]*Version, error) {
+	s.addIncompat(&incompat{terms: []term{s.newTerm(rootPackage, false, "")}, cause: causeRoot})
+
+	next := rootPackage
+	for {
+		if err := s.propagate(next); err != nil {
+			return nil, err
+		}
+		var done bool
+		next, done = s.decide()
+		if done {
+			break
+		}
+	}
+
+	picked := map[string]*Version{}
+	for name, i := range s.decisions {
+		if name != rootPackage {
+			picked[name] = s.packages[name][i].ver
+		}
+	}
+	return picked, nil
+}
+
+// propagate - derive what the incompats say must be true, given the
+//  partial solution, starting with those about pkg
+func (s *solver) propagate(pkg string) error {
+	changed := []string{pkg}
+	for len(changed) > 0 {
+		pkg, changed = changed[0], changed[1:]
+		incompats := s.incompats[pkg]
+		for i := len(incompats) - 1; i >= 0; i-- {
+			derived, conflict := s.propagateIncompat(incompats[i])
+			if conflict {
+				cause, err := s.resolveConflict(incompats[i])
+				if err != nil {
+					return err
+				}
+				derived, _ = s.propagateIncompat(cause)
+				changed = []string{*derived}
+				break
+			}
+			if derived != nil {
+				changed = appendUnique(changed, *derived)
+			}
+		}
+	}
+	return nil
+}
+
+// propagateIncompat - if all but one term of the incompat is satisfied,
+//  derive the opposite of the last one and return its package.
+//  Returns true if every term is satisfied
+func (s *solver) propagateIncompat(inc *incompat) (*string, bool) {
+	var unsatisfied *term
+	for i, t := range inc.terms {
+		switch s.relation(t) {
+		case relContradicted:
+			return nil, false
+		case relInconclusive:
+			if unsatisfied != nil {
+				return nil, false
+			}
+			unsatisfied = &inc.terms[i]
+		}
+	}
+	if unsatisfied == nil {
+		return nil, true
+	}
+	s.assign(unsatisfied.inverse(), inc)
+	return &unsatisfied.pkg, false
+}
+
+// resolveConflict - find the root cause of an incompat satisfied by the
+//  partial solution, backtrack to where it can be avoided and return it.
+//  Returns NoSolution if the root cause is the requirements themselves
+func (s *solver) resolveConflict(inc *incompat) (*incompat, error) {
+	learned := false
+	for !s.isFailure(inc) {
+		var recent *assignment
+		var recentTerm, difference *term
+		recentIndex := -1
+		previousLevel := 1
+		for i, t := range inc.terms {
+			index := s.satisfier(t)
+			satisfier := s.assignments[index]
+			switch {
+			case recent == nil:
+				recent, recentTerm, recentIndex = satisfier, &inc.terms[i], index
+			case recentIndex < index:
+				previousLevel = maxInt(previousLevel, recent.level)
+				recent, recentTerm, recentIndex = satisfier, &inc.terms[i], index
+				difference = nil
+			default:
+				previousLevel = maxInt(previousLevel, satisfier.level)
+			}
+
+			if recentTerm == &inc.terms[i] {
+				// if the satisfier alone does not satisfy the term, whatever
+				//  satisfies the rest of it must also be undone
+				difference = nil
+				diff := recent.term.intersect(recentTerm.inverse())
+				if !diff.isEmpty() {
+					difference = &diff
+					other := s.assignments[s.satisfier(diff.inverse())]
+					previousLevel = maxInt(previousLevel, other.level)
+				}
+			}
+		}
+
+		if previousLevel < recent.level || recent.cause == nil {
+			s.backtrack(previousLevel)
+			if learned {
+				s.addIncompat(inc)
+			}
+			return inc, nil
+		}
+
+		terms := []term{}
+		for _, t := range inc.terms {
+			if t.pkg != recentTerm.pkg {
+				terms = append(terms, t)
+			}
+		}
+		for _, t := range recent.cause.terms {
+			if t.pkg != recent.pkg {
+				terms = append(terms, t)
+			}
+		}
+		if difference != nil {
+			terms = append(terms, difference.inverse())
+		}
+		inc = s.newIncompat(terms, inc, recent.cause)
+		learned = true
+	}
+	return nil, fmt.Errorf("%w:\n%s", NoSolution, s.explain(inc))
+}
+
+// decide - pick the newest allowed version of a package that must be
+//  picked but has not been, and add the incompats for its dependencies.
+//  Returns the package, or true if there is nothing left to pick
+func (s *solver) decide() (string, bool) {
+	var next *term
+	for _, t := range s.unsatisfied() {
+		if next == nil || t.set.count() < next.set.count() ||
+			(t.set.count() == next.set.count() && t.pkg < next.pkg) {
+			t := t
+			next = &t
+		}
+	}
+	if next == nil {
+		return "", true
+	}
+
+	picked := -1
+	for i := range s.packages[next.pkg] {
+		if next.set[i] {
+			picked = i
+			break
+		}
+	}
+	if picked < 0 {
+		s.addIncompat(&incompat{terms: []term{*next}, cause: causeNoVersions})
+		return next.pkg, false
+	}
+
+	rel := s.packages[next.pkg][picked]
+	conflict := false
+	for _, dep := range sortedKeys(rel.deps) {
+		version := s.newTerm(next.pkg, true, rel.ver.String())
+		version.set = s.emptySet(next.pkg)
+		version.set[picked] = true
+
+		required := s.newTerm(dep, true, rel.deps[dep].String())
+		required.set = s.emptySet(dep)
+		for i, depRel := range s.packages[dep] {
+			required.set[i] = rel.deps[dep].Check(depRel.ver)
+		}
+		if required.set.count() == 0 {
+			required.set[len(required.set)-1] = true
+		}
+
+		inc := &incompat{terms: []term{version, required.inverse()}, cause: causeDependency}
+		s.addIncompat(inc)
+		// a dependency already ruled out means this version is too, and
+		//  propagating the incompat will say so
+		conflict = conflict || s.relation(required.inverse()) == relSatisfied
+	}
+
+	if !conflict {
+		t := s.newTerm(next.pkg, true, "")
+		t.set = s.emptySet(next.pkg)
+		t.set[picked] = true
+		s.assign(t, nil)
+		s.decisions[next.pkg] = picked
+	}
+	return next.pkg, false
+}
+
+// unsatisfied - the positive terms of the partial solution for packages
+//  with no version picked, in the order they were first assigned
+func (s *solver) unsatisfied() []term {
+	terms := []term{}
+	seen := map[string]bool{}
+	for _, a := range s.assignments {
+		if seen[a.pkg] {
+			continue
+		}
+		seen[a.pkg] = true
+		if _, ok := s.decisions[a.pkg]; ok {
+			continue
+		}
+		if t, _ := s.solutionTerm(a.pkg, len(s.assignments)); t.pos {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// assign - add a term to the partial solution, a decision if cause is nil
+func (s *solver) assign(t term, cause *incompat) {
+	level := len(s.decisions)
+	if cause == nil {
+		level++
+	}
+	s.assignments = append(s.assignments, &assignment{term: t, level: level, cause: cause})
+}
+
+// backtrack - undo the assignments made after the decision level
+func (s *solver) backtrack(level int) {
+	for len(s.assignments) > 0 && s.assignments[len(s.assignments)-1].level > level {
+		last := s.assignments[len(s.assignments)-1]
+		if last.cause == nil {
+			delete(s.decisions, last.pkg)
+		}
+		s.assignments = s.assignments[:len(s.assignments)-1]
+	}
+}
+
+// solutionTerm - what the first n assignments say about a package, false if
+//  they say nothing
+func (s *solver) solutionTerm(pkg string, n int) (term, bool) {
+	var t term
+	found := false
+	for _, a := range s.assignments[:n] {
+		if a.pkg != pkg {
+			continue
+		}
+		if !found {
+			t, found = a.term, true
+		} else {
+			t = t.intersect(a.term)
+		}
+	}
+	return t, found
+}
+
+// relation - how a term relates to the partial solution
+func (s *solver) relation(t term) int {
+	solution, ok := s.solutionTerm(t.pkg, len(s.assignments))
+	switch {
+	case !ok:
+		return relInconclusive
+	case solution.satisfies(t):
+		return relSatisfied
+	case solution.disjoint(t):
+		return relContradicted
+	}
+	return relInconclusive
+}
+
+// satisfier - the index of the first assignment at which the partial
+//  solution satisfies the term
+func (s *solver) satisfier(t term) int {
+	var solution term
+	found := false
+	for i, a := range s.assignments {
+		if a.pkg != t.pkg {
+			continue
+		}
+		if !found {
+			solution, found = a.term, true
+		} else {
+			solution = solution.intersect(a.term)
+		}
+		if solution.satisfies(t) {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("version: %s is not satisfied by the partial solution", s.termString(t)))
+}
+
+// isFailure - whether the incompat says the requirements cannot be met
+func (s *solver) isFailure(inc *incompat) bool {
+	return len(inc.terms) == 0 || (len(inc.terms) == 1 && inc.terms[0].pos && inc.terms[0].pkg == rootPackage)
+}
+
+// addIncompat - add an incompat, indexed by each of its packages
+func (s *solver) addIncompat(inc *incompat) {
+	for _, t := range inc.terms {
+		s.incompats[t.pkg] = append(s.incompats[t.pkg], inc)
+	}
+}
+
+// newIncompat - an incompat derived from two others, with the terms for
+//  each package merged
+func (s *solver) newIncompat(terms []term, from1, from2 *incompat) *incompat {
+	merged := []term{}
+	index := map[string]int{}
+	for _, t := range terms {
+		if i, ok := index[t.pkg]; ok {
+			merged[i] = merged[i].intersect(t)
+			continue
+		}
+		index[t.pkg] = len(merged)
+		merged = append(merged, t)
+	}
+
+	// the root is always picked, so saying so adds nothing
+	if len(merged) > 1 {
+		kept := []term{}
+		for _, t := range merged {
+			if !t.pos || t.pkg != rootPackage {
+				kept = append(kept, t)
+			}
+		}
+		merged = kept
+	}
+	return &incompat{terms: merged, cause: causeConflict, from: [2]*incompat{from1, from2}}
+}
+
+// newTerm - a term for every version of a package, including the ones that
+//  do not exist
+func (s *solver) newTerm(pkg string, pos bool, desc string) term {
+	set := s.emptySet(pkg)
+	for i := range set {
+		set[i] = true
+	}
+	return term{pkg: pkg, pos: pos, set: set, desc: desc}
+}
+
+// emptySet - a set of none of the versions of a package
+func (s *solver) emptySet(pkg string) versionSet {
+	return make(versionSet, len(s.packages[pkg])+1)
+}
+
+// inverse - the opposite of the term
+func (t term) inverse() term {
+	return term{pkg: t.pkg, pos: !t.pos, set: t.set, desc: t.desc}
+}
+
+// intersect - the term for both terms being true, they must be for the
+//  same package
+func (t term) intersect(t2 term) term {
+	switch {
+	case t.pos && t2.pos:
+		return t.keepDesc(t2, true, t.set.and(t2.set))
+	case t.pos:
+		return t.keepDesc(t2, true, t.set.andNot(t2.set))
+	case t2.pos:
+		return t.keepDesc(t2, true, t2.set.andNot(t.set))
+	}
+	return t.keepDesc(t2, false, t.set.or(t2.set))
+}
+
+// keepDesc - a term with the set, keeping the description of t or t2 if
+//  it is the same term
+func (t term) keepDesc(t2 term, pos bool, set versionSet) term {
+	result := term{pkg: t.pkg, pos: pos, set: set}
+	for _, from := range []term{t, t2} {
+		if from.pos == pos && from.set.equal(set) {
+			result.desc = from.desc
+			break
+		}
+	}
+	return result
+}
+
+// satisfies - whether t being true means t2 is
+func (t term) satisfies(t2 term) bool {
+	switch {
+	case t.pos && t2.pos:
+		return t.set.subsetOf(t2.set)
+	case t.pos:
+		return t.set.and(t2.set).count() == 0
+	case t2.pos:
+		return false
+	}
+	return t2.set.subsetOf(t.set)
+}
+
+// disjoint - whether t and t2 cannot both be true
+func (t term) disjoint(t2 term) bool {
+	switch {
+	case t.pos && t2.pos:
+		return t.set.and(t2.set).count() == 0
+	case t.pos:
+		return t.set.subsetOf(t2.set)
+	case t2.pos:
+		return t2.set.subsetOf(t.set)
+	}
+	return false
+}
+
+// isEmpty - whether the term can never be true
+func (t term) isEmpty() bool {
+	return t.pos && t.set.count() == 0
+}
+
+// and - the versions in both sets
+func (vs versionSet) and(vs2 versionSet) versionSet {
+	result := make(versionSet, len(vs))
+	for i := range vs {
+		result[i] = vs[i] && vs2[i]
+	}
+	return result
+}
+
+// or - the versions in either set
+func (vs versionSet) or(vs2 versionSet) versionSet {
+	result := make(versionSet, len(vs))
+	for i := range vs {
+		result[i] = vs[i] || vs2[i]
+	}
+	return result
+}
+
+// andNot - the versions in vs but not in vs2
+func (vs versionSet) andNot(vs2 versionSet) versionSet {
+	result := make(versionSet, len(vs))
+	for i := range vs {
+		result[i] = vs[i] && !vs2[i]
+	}
+	return result
+}
+
+// subsetOf - whether every version in vs is in vs2
+func (vs versionSet) subsetOf(vs2 versionSet) bool {
+	for i := range vs {
+		if vs[i] && !vs2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equal - whether the sets have the same versions
+func (vs versionSet) equal(vs2 versionSet) bool {
+	return vs.subsetOf(vs2) && vs2.subsetOf(vs)
+}
+
+// count - the number of versions in the set
+func (vs versionSet) count() int {
+	n := 0
+	for _, in := range vs {
+		if in {
+			n++
+		}
+	}
+	return n
+}
+
+// sortedKeys - the keys of a map, sorted, for a deterministic order
+func sortedKeys(m map[string]*Constraint) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// appendUnique - append the string if it is not already in the list
+func appendUnique(list []string, s string) []string {
+	for _, str := range list {
+		if str == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// maxInt - the larger of two ints
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// explainer - writes why an incompat is true, as the steps that derived it,
+//  see https://github.com/dart-lang/pub/blob/master/doc/solver.md#error-reporting
+type explainer struct {
+	s       *solver
+	uses    map[*incompat]int // how many times each incompat is used
+	numbers map[*incompat]int // the line number of incompats used more than once
+	lines   []explainLine
+}
+
+type explainLine struct {
+	text   string
+	number int
+}
+
+// explain - describe how the failure was derived, one step per line,
+//  numbering the lines that are referred to again later
+func (s *solver) explain(failure *incompat) string {
+	e := &explainer{s: s, uses: map[*incompat]int{}, numbers: map[*incompat]int{}}
+	if failure.cause != causeConflict {
+		return "Because " + s.incompatString(failure) + ", version solving failed."
+	}
+	e.count(failure)
+	e.visit(failure, failure, false)
+
+	width := 0
+	if len(e.numbers) > 0 {
+		width = len(fmt.Sprintf("(%d) ", len(e.numbers)))
+	}
+	out := make([]string, len(e.lines))
+	for i, line := range e.lines {
+		prefix := strings.Repeat(" ", width)
+		if line.number > 0 {
+			prefix = fmt.Sprintf("%-*s", width, fmt.Sprintf("(%d)", line.number))
+		}
+		if line.text == "" {
+			prefix = ""
+		}
+		out[i] = strings.TrimRight(prefix+line.text, " ")
+	}
+	return strings.Join(out, "\n")
+}
+
+// count - count the uses of each incompat in the derivation of inc
+func (e *explainer) count(inc *incompat) {
+	e.uses[inc]++
+	if e.uses[inc] == 1 && inc.cause == causeConflict {
+		e.count(inc.from[0])
+		e.count(inc.from[1])
+	}
+}
+
+// write - add a line, numbered if it will be referred to again
+func (e *explainer) write(inc *incompat, text string, numbered bool) {
+	number := 0
+	if numbered {
+		number = len(e.numbers) + 1
+		e.numbers[inc] = number
+	}
+	e.lines = append(e.lines, explainLine{text: text, number: number})
+}
+
+// visit - write the lines explaining a derived incompat
+func (e *explainer) visit(inc, failure *incompat, conclusion bool) {
+	numbered := conclusion || e.uses[inc] > 1
+	conjunction := "And"
+	if conclusion || inc == failure {
+		conjunction = "So,"
+	}
+	str := e.s.incompatString(inc)
+	from1, from2 := inc.from[0], inc.from[1]
+
+	switch {
+	case from1.cause == causeConflict && from2.cause == causeConflict:
+		line1, line2 := e.numbers[from1], e.numbers[from2]
+		switch {
+		case line1 > 0 && line2 > 0:
+			e.write(inc, fmt.Sprintf("Because %s and %s, %s.",
+				e.withLine(from1, line1), e.withLine(from2, line2), str), numbered)
+		case line1 > 0 || line2 > 0:
+			with, without := from1, from2
+			if line2 > 0 {
+				with, without = from2, from1
+			}
+			e.visit(without, failure, false)
+			e.write(inc, fmt.Sprintf("%s because %s, %s.", conjunction, e.withLine(with, e.numbers[with]), str), numbered)
+		case e.isSingleLine(from1) || e.isSingleLine(from2):
+			first, second := from1, from2
+			if e.isSingleLine(from2) {
+				first, second = from2, from1
+			}
+			e.visit(first, failure, false)
+			e.visit(second, failure, false)
+			e.write(inc, fmt.Sprintf("Thus, %s.", str), numbered)
+		default:
+			e.visit(from1, failure, true)
+			e.lines = append(e.lines, explainLine{})
+			e.visit(from2, failure, false)
+			e.write(inc, fmt.Sprintf("%s because %s, %s.", conjunction, e.withLine(from1, e.numbers[from1]), str), numbered)
+		}
+
+	case from1.cause == causeConflict || from2.cause == causeConflict:
+		derived, external := from1, from2
+		if from2.cause == causeConflict {
+			derived, external = from2, from1
+		}
+		switch {
+		case e.numbers[derived] > 0:
+			e.write(inc, fmt.Sprintf("Because %s and %s, %s.",
+				e.s.incompatString(external), e.withLine(derived, e.numbers[derived]), str), numbered)
+		case e.isCollapsible(derived):
+			inner, innerExternal := derived.from[0], derived.from[1]
+			if inner.cause != causeConflict {
+				inner, innerExternal = innerExternal, inner
+			}
+			e.visit(inner, failure, false)
+			e.write(inc, fmt.Sprintf("%s because %s and %s, %s.", conjunction,
+				e.s.incompatString(innerExternal), e.s.incompatString(external), str), numbered)
+		default:
+			e.visit(derived, failure, false)
+			e.write(inc, fmt.Sprintf("%s because %s, %s.", conjunction, e.s.incompatString(external), str), numbered)
+		}
+
+	default:
+		e.write(inc, fmt.Sprintf("Because %s and %s, %s.",
+			e.s.incompatString(from1), e.s.incompatString(from2), str), numbered)
+	}
+}
+
+// withLine - the incompat, with the line number it was written on
+func (e *explainer) withLine(inc *incompat, line int) string {
+	return fmt.Sprintf("%s (%d)", e.s.incompatString(inc), line)
+}
+
+// isSingleLine - whether a derived incompat comes straight from two that
+//  are not derived
+func (e *explainer) isSingleLine(inc *incompat) bool {
+	return inc.from[0].cause != causeConflict && inc.from[1].cause != causeConflict
+}
+
+// isCollapsible - whether a derived incompat used once, from one derived and
+//  one other incompat, can be written as part of the line using it
+func (e *explainer) isCollapsible(inc *incompat) bool {
+	if e.uses[inc] > 1 {
+		return false
+	}
+	derived1, derived2 := inc.from[0].cause == causeConflict, inc.from[1].cause == causeConflict
+	if derived1 == derived2 {
+		return false
+	}
+	complex := inc.from[0]
+	if derived2 {
+		complex = inc.from[1]
+	}
+	return e.numbers[complex] == 0
+}
+
+// incompatString - describe what an incompat says
+func (s *solver) incompatString(inc *incompat) string {
+	terms := inc.terms
+	switch {
+	case s.isFailure(inc):
+		return "version solving failed"
+	case inc.cause == causeDependency:
+		return fmt.Sprintf("%s depends on %s", s.termString(terms[0]), s.termString(terms[1].inverse()))
+	case inc.cause == causeNoVersions:
+		return fmt.Sprintf("no versions of %s match %s", s.pkgString(terms[0].pkg), s.setString(terms[0]))
+	case len(terms) == 1 && terms[0].pos:
+		return s.termString(terms[0]) + " is forbidden"
+	case len(terms) == 1:
+		return s.termString(terms[0].inverse()) + " is required"
+	case len(terms) == 2 && terms[0].pos && terms[1].pos:
+		return fmt.Sprintf("%s is incompatible with %s", s.termString(terms[0]), s.termString(terms[1]))
+	case len(terms) == 2 && terms[0].pos != terms[1].pos:
+		positive, negative := terms[0], terms[1]
+		if !positive.pos {
+			positive, negative = negative, positive
+		}
+		return fmt.Sprintf("%s requires %s", s.termString(positive), s.termString(negative.inverse()))
+	case len(terms) == 2:
+		return fmt.Sprintf("either %s or %s", s.termString(terms[0].inverse()), s.termString(terms[1].inverse()))
+	}
+
+	positive, negative := []string{}, []string{}
+	for _, t := range terms {
+		if t.pos {
+			positive = append(positive, s.termString(t))
+		} else {
+			negative = append(negative, s.termString(t.inverse()))
+		}
+	}
+	switch {
+	case len(negative) == 0:
+		return fmt.Sprintf("one of %s must be false", strings.Join(positive, " or "))
+	case len(positive) == 0:
+		return fmt.Sprintf("one of %s must be true", strings.Join(negative, " or "))
+	case len(positive) == 1:
+		return fmt.Sprintf("%s requires %s", positive[0], strings.Join(negative, " or "))
+	}
+	return fmt.Sprintf("if %s then %s", strings.Join(positive, " and "), strings.Join(negative, " or "))
+}
+
+// termString - describe a term, eg "db ^1.4" or "not db 1.2.0"
+func (s *solver) termString(t term) string {
+	if t.pkg == rootPackage {
+		return s.pkgString(t.pkg)
+	}
+	str := s.pkgString(t.pkg) + " " + s.setString(t)
+	if !t.pos {
+		str = "not " + str
+	}
+	return str
+}
+
+// pkgString - the name of the package, "root" for the requirements
+func (s *solver) pkgString(pkg string) string {
+	if pkg == rootPackage {
+		return "root"
+	}
+	return pkg
+}
+
+// setString - describe the versions of a term, as it was written if it
+//  was, otherwise as the ranges of the versions of the package it has
+func (s *solver) setString(t term) string {
+	if t.desc != "" {
+		return t.desc
+	}
+	releases := s.packages[t.pkg]
+	set := t.set[:len(releases)]
+	switch set.count() {
+	case 0:
+		return "none"
+	case len(set):
+		return "any"
+	}
+
+	// releases are newest first, so find the ranges from the oldest
+	ranges := []string{}
+	for i := len(set) - 1; i >= 0; i-- {
+		if !set[i] {
+			continue
+		}
+		j := i
+		for j > 0 && set[j-1] {
+			j--
+		}
+		oldest, newest := releases[i].ver, releases[j].ver
+		switch {
+		case i == j:
+			ranges = append(ranges, oldest.String())
+		case i == len(set)-1:
+			ranges = append(ranges, "<="+newest.String())
+		case j == 0:
+			ranges = append(ranges, ">="+oldest.String())
+		default:
+			ranges = append(ranges, fmt.Sprintf(">=%s <=%s", oldest, newest))
+		}
+		i = j
+	}
+	return strings.Join(ranges, " || ")
+}
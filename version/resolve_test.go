@@ -0,0 +1,323 @@
+package version
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// registry - package name to version to dependencies
+type registry map[string]map[string]map[string]string
+
+// resolverOf - a Resolver with the packages of the registry
+func resolverOf(require *require.Assertions, packages registry) *Resolver {
+	r := NewResolver()
+	for name, versions := range packages {
+		for ver, deps := range versions {
+			require.NoError(r.Add(name, ver, deps), "%s %s should be added", name, ver)
+		}
+	}
+	return r
+}
+
+func TestResolve(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		packages     registry
+		requirements map[string]string
+		want         map[string]string
+	}{
+		"no conflicts": {
+			packages: registry{
+				"foo": {"1.0.0": {"bar": "^1.0.0"}},
+				"bar": {"1.0.0": nil, "2.0.0": nil},
+			},
+			requirements: map[string]string{"foo": "^1.0.0"},
+			want:         map[string]string{"foo": "1.0.0", "bar": "1.0.0"},
+		},
+		"newest versions": {
+			packages: registry{
+				"api": {"1.0": {"db": ">=1"}, "1.2": {"db": ">=1"}, "1.10": {"db": ">=1"}},
+				"db":  {"1.0": nil, "1.5": nil, "1.5.1": nil},
+			},
+			requirements: map[string]string{"api": "*"},
+			want:         map[string]string{"api": "1.10", "db": "1.5.1"},
+		},
+		"avoiding a conflict while deciding": {
+			packages: registry{
+				"foo": {"1.0.0": nil, "1.1.0": {"bar": "^2.0.0"}},
+				"bar": {"1.0.0": nil, "1.1.0": nil, "2.0.0": nil},
+			},
+			requirements: map[string]string{"foo": "^1.0.0", "bar": "^1.0.0"},
+			want:         map[string]string{"foo": "1.0.0", "bar": "1.1.0"},
+		},
+		"resolving a conflict": {
+			packages: registry{
+				"foo": {"1.0.0": nil, "2.0.0": {"bar": "^1.0.0"}},
+				"bar": {"1.0.0": {"foo": "^1.0.0"}},
+			},
+			requirements: map[string]string{"foo": ">=1.0.0"},
+			want:         map[string]string{"foo": "1.0.0"},
+		},
+		"resolving a conflict with a partial satisfier": {
+			packages: registry{
+				"foo":    {"1.0.0": nil, "1.1.0": {"left": "^1.0.0", "right": "^1.0.0"}},
+				"left":   {"1.0.0": {"shared": ">=1.0.0"}},
+				"right":  {"1.0.0": {"shared": "<2.0.0"}},
+				"shared": {"1.0.0": {"target": "^1.0.0"}, "2.0.0": nil},
+				"target": {"1.0.0": nil, "2.0.0": nil},
+			},
+			requirements: map[string]string{"foo": "^1.0.0", "target": "^2.0.0"},
+			want:         map[string]string{"foo": "1.0.0", "target": "2.0.0"},
+		},
+		"backtracking a level": {
+			packages: registry{
+				"a": {"1.0.0": {"x": ">=1.0.0"}},
+				"b": {"1.0.0": {"y": ">=1.0.0"}},
+				"x": {"1.0.0": nil, "2.0.0": {"y": "^1"}},
+				"y": {"1.0.0": nil, "2.0.0": {"x": "^1"}},
+			},
+			requirements: map[string]string{"a": "*", "b": "*"},
+			want:         map[string]string{"a": "1.0.0", "b": "1.0.0", "x": "2.0.0", "y": "1.0.0"},
+		},
+		"four level versions": {
+			packages: registry{
+				"svc":  {"4.1.0.7": {"core": "~4.1"}, "4.2.0.1": {"core": "~4.2"}},
+				"core": {"4.1.9.9": nil, "4.2.0.0": nil, "4.3.0.0": nil},
+			},
+			requirements: map[string]string{"svc": "*", "core": "<4.2"},
+			want:         map[string]string{"svc": "4.1.0.7", "core": "4.1.9.9"},
+		},
+		"pre-releases": {
+			packages: registry{
+				"app": {"2.0.0-rc.1": nil, "2.0.0-rc.2": nil, "1.9.0": nil},
+			},
+			requirements: map[string]string{"app": ">=2.0.0-rc.1"},
+			want:         map[string]string{"app": "2.0.0-rc.2"},
+		},
+		"no requirements": {
+			packages:     registry{"foo": {"1.0.0": nil}},
+			requirements: map[string]string{},
+			want:         map[string]string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := resolverOf(require, tc.packages)
+			picked, err := r.Resolve(tc.requirements)
+			require.NoError(err)
+
+			got := map[string]string{}
+			for pkg, ver := range picked {
+				got[pkg] = ver.String()
+			}
+			require.Equal(tc.want, got)
+		})
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		packages     registry
+		requirements map[string]string
+		want         string
+	}{
+		"missing package": {
+			packages:     registry{"foo": {"1.0.0": {"bar": "^1"}}},
+			requirements: map[string]string{"foo": "*"},
+			want: "Because foo 1.0.0 depends on bar ^1 and no versions of bar match ^1, foo 1.0.0 is forbidden.\n" +
+				"So, because root depends on foo *, version solving failed.",
+		},
+		"no matching version": {
+			packages:     registry{"foo": {"1.0.0": nil, "1.1.0": nil}},
+			requirements: map[string]string{"foo": "^2"},
+			want:         "Because no versions of foo match ^2 and root depends on foo ^2, version solving failed.",
+		},
+		"linear": {
+			packages: registry{
+				"foo": {"1.0.0": {"bar": "^2.0.0"}},
+				"bar": {"2.0.0": {"baz": "^3.0.0"}},
+				"baz": {"1.0.0": nil, "3.0.0": nil},
+			},
+			requirements: map[string]string{"foo": "^1.0.0", "baz": "^1.0.0"},
+			want: "Because foo 1.0.0 depends on bar ^2.0.0 and bar 2.0.0 depends on baz ^3.0.0, foo 1.0.0 requires baz ^3.0.0.\n" +
+				"So, because root depends on baz ^1.0.0 and root depends on foo ^1.0.0, version solving failed.",
+		},
+		"branching": {
+			packages: registry{
+				"foo": {"1.0.0": {"a": "^1.0.0", "b": "^1.0.0"}, "1.1.0": {"x": "^1.0.0", "y": "^1.0.0"}},
+				"a":   {"1.0.0": {"b": "^2.0.0"}},
+				"b":   {"1.0.0": nil, "2.0.0": nil},
+				"x":   {"1.0.0": {"y": "^2.0.0"}},
+				"y":   {"1.0.0": nil, "2.0.0": nil},
+			},
+			requirements: map[string]string{"foo": "^1.0.0"},
+			want: "    Because a 1.0.0 depends on b ^2.0.0 and foo 1.0.0 depends on a ^1.0.0, foo 1.0.0 requires b ^2.0.0.\n" +
+				"(1) So, because foo 1.0.0 depends on b ^1.0.0, foo 1.0.0 is forbidden.\n" +
+				"\n" +
+				"    Because x 1.0.0 depends on y ^2.0.0 and foo 1.1.0 depends on x ^1.0.0, foo 1.1.0 requires y ^2.0.0.\n" +
+				"    And because foo 1.1.0 depends on y ^1.0.0, foo 1.1.0 is forbidden.\n" +
+				"    And because foo 1.0.0 is forbidden (1), foo any is forbidden.\n" +
+				"    So, because root depends on foo ^1.0.0, version solving failed.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := resolverOf(require, tc.packages)
+			picked, err := r.Resolve(tc.requirements)
+			require.Nil(picked)
+			require.ErrorIs(err, NoSolution)
+			require.Equal("No compatible set of versions:\n"+tc.want, err.Error())
+		})
+	}
+}
+
+func TestResolveDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	packages := registry{
+		"foo": {"1.0.0": {"a": "^1.0.0", "b": "^1.0.0"}, "1.1.0": {"x": "^1.0.0", "y": "^1.0.0"}},
+		"a":   {"1.0.0": {"b": "^2.0.0"}},
+		"b":   {"1.0.0": nil, "2.0.0": nil},
+		"x":   {"1.0.0": {"y": "^2.0.0"}},
+		"y":   {"1.0.0": nil, "2.0.0": nil},
+	}
+	_, want := resolverOf(require, packages).Resolve(map[string]string{"foo": "^1.0.0"})
+	for i := 0; i < 20; i++ {
+		_, err := resolverOf(require, packages).Resolve(map[string]string{"foo": "^1.0.0"})
+		require.Equal(want.Error(), err.Error(), "should explain the conflict the same way every time")
+	}
+}
+
+func TestResolveBruteForce(t *testing.T) {
+	require := require.New(t)
+
+	names := []string{"a", "b", "c", "d"}
+	versions := []string{"1.0.0", "1.1.0", "2.0.0"}
+	constraints := []string{"^1", "^2", ">=1.1", "<1.1", "1.0.0 || 2.0.0", "*"}
+	rnd := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 300; n++ {
+		packages := registry{}
+		for _, name := range names {
+			packages[name] = map[string]map[string]string{}
+			for _, ver := range versions {
+				if rnd.Intn(4) == 0 {
+					continue
+				}
+				deps := map[string]string{}
+				for _, dep := range names {
+					if dep != name && rnd.Intn(3) == 0 {
+						deps[dep] = constraints[rnd.Intn(len(constraints))]
+					}
+				}
+				packages[name][ver] = deps
+			}
+		}
+		requirements := map[string]string{
+			names[rnd.Intn(len(names))]: constraints[rnd.Intn(len(constraints))],
+			names[rnd.Intn(len(names))]: constraints[rnd.Intn(len(constraints))],
+		}
+
+		picked, err := resolverOf(require, packages).Resolve(requirements)
+		if err != nil {
+			require.ErrorIs(err, NoSolution)
+			require.False(hasSolution(require, packages, requirements, map[string]string{}, names),
+				"%v with %v should have no solution: %v", packages, requirements, err)
+			continue
+		}
+
+		got := map[string]string{}
+		for name, ver := range picked {
+			got[name] = ver.String()
+		}
+		require.True(isSolution(require, packages, requirements, got),
+			"%v with %v should be solved by %v", packages, requirements, got)
+	}
+}
+
+// hasSolution - try every choice of a version, or none, for the packages
+//  not yet chosen
+func hasSolution(require *require.Assertions, packages registry, requirements, chosen map[string]string, names []string) bool {
+	if len(names) == 0 {
+		return isSolution(require, packages, requirements, chosen)
+	}
+	name, rest := names[0], names[1:]
+	if hasSolution(require, packages, requirements, chosen, rest) {
+		return true
+	}
+	for ver := range packages[name] {
+		chosen[name] = ver
+		found := hasSolution(require, packages, requirements, chosen, rest)
+		delete(chosen, name)
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// isSolution - check the chosen versions meet the requirements and their
+//  own dependencies
+func isSolution(require *require.Assertions, packages registry, requirements, chosen map[string]string) bool {
+	meets := func(constraints map[string]string) bool {
+		for name, constraint := range constraints {
+			ver, ok := chosen[name]
+			if !ok {
+				return false
+			}
+			c, err := NewConstraint(constraint)
+			require.NoError(err)
+			v, err := NewVersion(ver)
+			require.NoError(err)
+			if !c.Check(v) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !meets(requirements) {
+		return false
+	}
+	for name, ver := range chosen {
+		if !meets(packages[name][ver]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestResolverAdd(t *testing.T) {
+	require := require.New(t)
+
+	r := NewResolver()
+	require.NoError(r.Add("api", "1.2.0", map[string]string{"db": "^1"}))
+
+	testCases := map[string]struct {
+		name string
+		ver  string
+		deps map[string]string
+	}{
+		"no name":           {name: "", ver: "1.0.0"},
+		"bad version":       {name: "api", ver: "1.x"},
+		"already added":     {name: "api", ver: "1.2.0"},
+		"bad constraint":    {name: "api", ver: "1.3.0", deps: map[string]string{"db": "^^1"}},
+		"depends on itself": {name: "api", ver: "1.3.0", deps: map[string]string{"api": "^1"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(r.Add(tc.name, tc.ver, tc.deps), InvalidPackage)
+		})
+	}
+
+	_, err := r.Resolve(map[string]string{"api": "^^1"})
+	require.ErrorIs(err, InvalidPackage, "should reject a bad requirement")
+}
@@ -0,0 +1,234 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var InvalidRPM = fmt.Errorf("Invalid RPM version string")
+
+// RPM - an RPM package version, [epoch:]version[-release], compared as rpm
+//  does, eg 2.17-326.el7
+//  - epoch is a number, 0 if missing, and is compared first
+//  - release follows the last '-'
+//  The version and release are compared with rpmvercmp, see compareRPM
+type RPM struct {
+	asString string
+	epoch    int
+	version  string
+	release  string
+}
+
+// NewRPM - create an RPM version from a version string
+func NewRPM(s string) (*RPM, error) {
+	if s == "" {
+		return nil, InvalidVersion
+	}
+	if strings.IndexFunc(s, isSpace) >= 0 {
+		return nil, fmt.Errorf("%w: %q has embedded spaces", InvalidRPM, s)
+	}
+
+	r := &RPM{asString: s}
+	rest := s
+	if epoch, after, found := strings.Cut(s, ":"); found {
+		if !isNumeric(epoch) {
+			return nil, fmt.Errorf("%w: %q has a non-numeric epoch", InvalidRPM, s)
+		}
+		val, err := strconv.Atoi(epoch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q has an epoch that is too big", InvalidRPM, s)
+		}
+		r.epoch = val
+		rest = after
+	}
+
+	r.version = rest
+	if i := strings.LastIndex(rest, "-"); i >= 0 {
+		r.version, r.release = rest[:i], rest[i+1:]
+		if r.release == "" {
+			return nil, fmt.Errorf("%w: %q has an empty release", InvalidRPM, s)
+		}
+	}
+	if r.version == "" {
+		return nil, fmt.Errorf("%w: %q has an empty version", InvalidRPM, s)
+	}
+	if strings.ContainsAny(r.version, ":-") {
+		return nil, fmt.Errorf("%w: %q has a ':' or '-' in the version", InvalidRPM, s)
+	}
+
+	return r, nil
+}
+
+// String - print the version as a string
+func (r RPM) String() string {
+	return r.asString
+}
+
+// Epoch - return the epoch of the version, 0 if it has none
+func (r RPM) Epoch() int {
+	return r.epoch
+}
+
+// Version - return the version part of the version, without epoch or release
+func (r RPM) Version() string {
+	return r.version
+}
+
+// Release - return the release of the version, "" if it has none
+func (r RPM) Release() string {
+	return r.release
+}
+
+// LessThan - check if version is less than another version
+func (r RPM) LessThan(r2 *RPM) bool {
+	return r.Compare(r2) == -1
+}
+
+// GreaterThan - check if version is greater than another version
+func (r RPM) GreaterThan(r2 *RPM) bool {
+	return r.Compare(r2) == 1
+}
+
+// Equal - check if version is equal to another version
+func (r RPM) Equal(r2 *RPM) bool {
+	return r.Compare(r2) == 0
+}
+
+// Matches - check if the version matches another, as rpm matches a
+//  dependency, where a missing release matches any release, so 2.17 matches
+//  2.17-326.el7. Unlike Equal this is not transitive, 2.17-1 and 2.17-2
+//  both match 2.17 but not each other
+func (r RPM) Matches(r2 *RPM) bool {
+	if r.epoch != r2.epoch || compareRPM(r.version, r2.version) != 0 {
+		return false
+	}
+	return r.release == "" || r2.release == "" || compareRPM(r.release, r2.release) == 0
+}
+
+// Compare - compare two versions, return 0 if they are equal, -1 if r < r2, or 1 if r > r2
+//  Compares the epochs, then the versions, then the releases, where a missing
+//  release is lower than any release, so 2.17 < 2.17-1 < 2.17-326.el7.
+//  See Matches for rpm's rule that a missing release matches any
+func (r RPM) Compare(r2 *RPM) int {
+	if r.epoch != r2.epoch {
+		if r.epoch < r2.epoch {
+			return -1
+		}
+		return 1
+	}
+	if c := compareRPM(r.version, r2.version); c != 0 {
+		return c
+	}
+	switch {
+	case r.release == r2.release:
+		return 0
+	case r.release == "":
+		return -1
+	case r2.release == "":
+		return 1
+	}
+	return compareRPM(r.release, r2.release)
+}
+
+// compareRPM - compare two versions or releases as rpm's rpmvercmp, return
+//  0 if they are equal, -1 if a < b, or 1 if a > b.
+//  Each is split into segments of digits or of letters, with anything else a
+//  separator. Digit segments compare as numbers and are newer than letter
+//  segments, letters compare as strings. A '~' sorts before anything, even
+//  the end of the string, and a '^' after the end but before anything else
+func compareRPM(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isAlnum(a[i]) && a[i] != '~' && a[i] != '^' {
+			i++
+		}
+		for j < len(b) && !isAlnum(b[j]) && b[j] != '~' && b[j] != '^' {
+			j++
+		}
+
+		// a tilde sorts before everything else
+		if (i < len(a) && a[i] == '~') || (j < len(b) && b[j] == '~') {
+			if i >= len(a) || a[i] != '~' {
+				return 1
+			}
+			if j >= len(b) || b[j] != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		// a caret sorts after the end, but before anything else
+		if (i < len(a) && a[i] == '^') || (j < len(b) && b[j] == '^') {
+			if i >= len(a) {
+				return -1
+			}
+			if j >= len(b) {
+				return 1
+			}
+			if a[i] != '^' {
+				return 1
+			}
+			if b[j] != '^' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		// take the next segment of each, of the type a's starts with
+		segment := isAlpha
+		isNum := isDigit(a[i])
+		if isNum {
+			segment = isDigit
+		}
+		si, sj := i, j
+		for i < len(a) && segment(a[i]) {
+			i++
+		}
+		for j < len(b) && segment(b[j]) {
+			j++
+		}
+		segA, segB := a[si:i], b[sj:j]
+
+		// numbers are newer than letters
+		if segB == "" {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		if isNum {
+			segA, segB = strings.TrimLeft(segA, "0"), strings.TrimLeft(segB, "0")
+			if len(segA) != len(segB) {
+				if len(segA) > len(segB) {
+					return 1
+				}
+				return -1
+			}
+		}
+		if c := strings.Compare(segA, segB); c != 0 {
+			return c
+		}
+	}
+
+	if i >= len(a) && j >= len(b) {
+		return 0
+	}
+	if i >= len(a) {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,224 @@
+package version
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPMVerCmp(t *testing.T) {
+	require := require.New(t)
+
+	// the rpmvercmp cases from rpm's tests/rpmvercmp.at
+	testCases := []struct {
+		v1   string
+		v2   string
+		rslt int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "2.0", -1},
+		{"2.0", "1.0", 1},
+		{"2.0.1", "2.0.1", 0},
+		{"2.0", "2.0.1", -1},
+		{"2.0.1", "2.0", 1},
+		{"2.0.1a", "2.0.1a", 0},
+		{"2.0.1a", "2.0.1", 1},
+		{"2.0.1", "2.0.1a", -1},
+		{"5.5p1", "5.5p1", 0},
+		{"5.5p1", "5.5p2", -1},
+		{"5.5p2", "5.5p1", 1},
+		{"5.5p10", "5.5p10", 0},
+		{"5.5p1", "5.5p10", -1},
+		{"5.5p10", "5.5p1", 1},
+		{"10xyz", "10.1xyz", -1},
+		{"10.1xyz", "10xyz", 1},
+		{"xyz10", "xyz10", 0},
+		{"xyz10", "xyz10.1", -1},
+		{"xyz10.1", "xyz10", 1},
+		{"xyz.4", "xyz.4", 0},
+		{"xyz.4", "8", -1},
+		{"8", "xyz.4", 1},
+		{"xyz.4", "2", -1},
+		{"2", "xyz.4", 1},
+		{"5.5p2", "5.6p1", -1},
+		{"5.6p1", "5.5p2", 1},
+		{"5.6p1", "6.5p1", -1},
+		{"6.5p1", "5.6p1", 1},
+		{"6.0.rc1", "6.0", 1},
+		{"6.0", "6.0.rc1", -1},
+		{"10b2", "10a1", 1},
+		{"10a2", "10b2", -1},
+		{"1.0aa", "1.0aa", 0},
+		{"1.0a", "1.0aa", -1},
+		{"1.0aa", "1.0a", 1},
+		{"10.0001", "10.0001", 0},
+		{"10.0001", "10.1", 0},
+		{"10.1", "10.0001", 0},
+		{"10.0001", "10.0039", -1},
+		{"10.0039", "10.0001", 1},
+		{"4.999.9", "5.0", -1},
+		{"5.0", "4.999.9", 1},
+		{"20101121", "20101121", 0},
+		{"20101121", "20101122", -1},
+		{"20101122", "20101121", 1},
+		{"2_0", "2_0", 0},
+		{"2.0", "2_0", 0},
+		{"2_0", "2.0", 0},
+		{"a", "a", 0},
+		{"a+", "a+", 0},
+		{"a+", "a_", 0},
+		{"a_", "a+", 0},
+		{"+a", "+a", 0},
+		{"+a", "_a", 0},
+		{"_a", "+a", 0},
+		{"+_", "+_", 0},
+		{"_+", "+_", 0},
+		{"_+", "_+", 0},
+		{"+", "_", 0},
+		{"_", "+", 0},
+		{"1.0~rc1", "1.0~rc1", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0", "1.0~rc1", 1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc2", "1.0~rc1", 1},
+		{"1.0~rc1~git123", "1.0~rc1~git123", 0},
+		{"1.0~rc1~git123", "1.0~rc1", -1},
+		{"1.0~rc1", "1.0~rc1~git123", 1},
+		{"1.0^", "1.0^", 0},
+		{"1.0^", "1.0", 1},
+		{"1.0", "1.0^", -1},
+		{"1.0^git1", "1.0^git1", 0},
+		{"1.0^git1", "1.0", 1},
+		{"1.0", "1.0^git1", -1},
+		{"1.0^git1", "1.0^git2", -1},
+		{"1.0^git2", "1.0^git1", 1},
+		{"1.0^git1", "1.01", -1},
+		{"1.01", "1.0^git1", 1},
+		{"1.0^20160101", "1.0^20160101", 0},
+		{"1.0^20160101", "1.0.1", -1},
+		{"1.0.1", "1.0^20160101", 1},
+		{"1.0^20160101^git1", "1.0^20160101^git1", 0},
+		{"1.0^20160102", "1.0^20160101^git1", 1},
+		{"1.0^20160101^git1", "1.0^20160102", -1},
+		{"1.0~rc1^git1", "1.0~rc1^git1", 0},
+		{"1.0~rc1^git1", "1.0~rc1", 1},
+		{"1.0~rc1", "1.0~rc1^git1", -1},
+		{"1.0^git1~pre", "1.0^git1~pre", 0},
+		{"1.0^git1", "1.0^git1~pre", 1},
+		{"1.0^git1~pre", "1.0^git1", -1},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(tc.rslt, compareRPM(tc.v1, tc.v2), "rpmvercmp(%s, %s)", tc.v1, tc.v2)
+
+		r1, err := NewRPM(tc.v1)
+		require.NoError(err, "should create the version %q", tc.v1)
+		r2, err := NewRPM(tc.v2)
+		require.NoError(err, "should create the version %q", tc.v2)
+		require.Equal(tc.rslt, r1.Compare(r2), "Compare(%s, %s)", tc.v1, tc.v2)
+	}
+}
+
+func TestRPM(t *testing.T) {
+	require := require.New(t)
+
+	r, err := NewRPM("1:2.17-326.el7")
+	require.NoError(err, "should create the version")
+	require.Equal("1:2.17-326.el7", r.String(), "should have the expected string")
+	require.Equal(1, r.Epoch(), "should have the epoch")
+	require.Equal("2.17", r.Version(), "should have the version")
+	require.Equal("326.el7", r.Release(), "should have the release")
+
+	testCases := map[string]struct {
+		v1   string
+		v2   string
+		rslt int
+	}{
+		"epoch first": {
+			v1:   "1:1.0-1",
+			v2:   "2.0-1",
+			rslt: 1,
+		},
+		"missing epoch is 0": {
+			v1:   "0:2.17-326.el7",
+			v2:   "2.17-326.el7",
+			rslt: 0,
+		},
+		"release": {
+			v1:   "2.17-326.el7",
+			v2:   "2.17-325.el7_9",
+			rslt: 1,
+		},
+		"release, dist tag": {
+			v1:   "2.17-326.el7",
+			v2:   "2.17-326.el7_9",
+			rslt: -1,
+		},
+		"missing release is lower": {
+			v1:   "2.17",
+			v2:   "2.17-326.el7",
+			rslt: -1,
+		},
+		"version before release": {
+			v1:   "2.17-999",
+			v2:   "2.18-1",
+			rslt: -1,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			r1, _ := NewRPM(tc.v1)
+			require.NotNil(r1, "should create the version")
+			r2, _ := NewRPM(tc.v2)
+			require.NotNil(r2, "should create the version")
+
+			require.Equal(tc.rslt, r1.Compare(r2), "Compare should give the expected result")
+			require.Equal(tc.rslt == -1, r1.LessThan(r2), "LessThan should give the expected result")
+			require.Equal(tc.rslt == 1, r1.GreaterThan(r2), "GreaterThan should give the expected result")
+			require.Equal(tc.rslt == 0, r1.Equal(r2), "Equal should give the expected result")
+		})
+	}
+
+	testMatches := map[string]struct {
+		v1    string
+		v2    string
+		match bool
+	}{
+		"same":                  {v1: "2.17-1", v2: "2.17-1", match: true},
+		"missing release":       {v1: "2.17", v2: "2.17-1", match: true},
+		"missing release, both": {v1: "2.17-2", v2: "2.17", match: true},
+		"different release":     {v1: "2.17-1", v2: "2.17-2", match: false},
+		"different version":     {v1: "2.17", v2: "2.18-1", match: false},
+		"different epoch":       {v1: "1:2.17", v2: "2.17-1", match: false},
+		"missing epoch is 0":    {v1: "0:2.17", v2: "2.17-1", match: true},
+	}
+	for tn, tc := range testMatches {
+		r1, _ := NewRPM(tc.v1)
+		r2, _ := NewRPM(tc.v2)
+		require.Equal(tc.match, r1.Matches(r2), "%s: Matches should give the expected result", tn)
+		require.Equal(tc.match, r2.Matches(r1), "%s: Matches should be symmetric", tn)
+	}
+
+	// Compare is a total order, even with missing releases
+	c := []*RPM{}
+	for _, s := range []string{"2.17-2", "2.17", "2.17-1", "1:2.17", "2.16-9"} {
+		r, _ := NewRPM(s)
+		c = append(c, r)
+	}
+	sort.Slice(c, func(i, j int) bool { return c[i].LessThan(c[j]) })
+	sorted := []string{}
+	for _, r := range c {
+		sorted = append(sorted, r.String())
+	}
+	require.Equal([]string{"2.16-9", "2.17", "2.17-1", "2.17-2", "1:2.17"}, sorted, "should sort missing releases first")
+
+	for _, s := range []string{"1.0-", "-1", "x:1.0", ":1.0", "1.0 -1", "1:2:3", "99999999999999999999:1"} {
+		r, err := NewRPM(s)
+		require.Nil(r, "%q should not return a version", s)
+		require.ErrorIs(err, InvalidRPM, "%q should return the expected error", s)
+	}
+	_, err = NewRPM("")
+	require.Equal(InvalidVersion, err, "should reject an empty string")
+}
@@ -0,0 +1,164 @@
+package version
+
+import (
+	"strings"
+)
+
+// NewSemVer - create a Version from a Semantic Versioning 2.0.0 string,
+//  major.minor.patch[-pre-release][+build], eg 1.2.3-rc.1+build.5
+//  - major, minor and patch are numbers without leading zeros, of any length
+//  - pre-release and build are '.' separated identifiers of [0-9A-Za-z-]
+//  - numeric pre-release identifiers cannot have leading zeros
+//  Takes the same options as NewVersion. A string that cannot be parsed
+//  returns a *ParseError, as NewVersion does, whose Index is the identifier
+//  for a problem in the pre-release or build metadata
+func NewSemVer(s string, opts ...Option) (*Version, error) {
+	fail := func(offset, index int, err error) (*Version, error) {
+		return nil, &ParseError{Input: s, Offset: offset, Index: index, Err: err}
+	}
+	if s == "" {
+		return fail(0, 0, InvalidVersion)
+	}
+
+	core, build, hasBuild := strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(core, "-")
+
+	v := &Version{
+		asString: s,
+	}
+	if err := applyOptions(v, opts); err != nil {
+		return nil, err
+	}
+
+	switch dots := strings.Count(core, "."); {
+	case dots < 2:
+		// the missing levels would be at the end of the core
+		return fail(len(core), dots+1, InvalidSemVer)
+	case dots > 2:
+		// the third '.' starts a level SemVer does not have
+		third := len(strings.Join(strings.SplitN(core, ".", 4)[:3], "."))
+		return fail(third, 3, InvalidSemVer)
+	}
+	levels, err := lexLevels(core, RejectLeadingZeros)
+	if err != nil {
+		// the core is the start of s, so offsets in it are offsets in s
+		err.(*ParseError).Input = s
+		return nil, err
+	}
+	v.asArray = levels
+
+	if hasPre {
+		v.pre = strings.Split(pre, ".")
+		offset := len(core) + 1
+		for i, id := range v.pre {
+			if bad := badIdentifier(id); bad >= 0 {
+				return fail(offset+bad, i, InvalidPrerelease)
+			}
+			if len(id) > 1 && id[0] == '0' && isNumeric(id) {
+				return fail(offset, i, InvalidPrerelease)
+			}
+			offset += len(id) + 1
+		}
+	}
+
+	if hasBuild {
+		offset := len(s) - len(build)
+		for i, id := range strings.Split(build, ".") {
+			if bad := badIdentifier(id); bad >= 0 {
+				return fail(offset+bad, i, InvalidMetadata)
+			}
+			offset += len(id) + 1
+		}
+		v.build = build
+	}
+
+	return v, nil
+}
+
+// Prerelease - return the pre-release part of the version, "" if there is none
+func (v Version) Prerelease() string {
+	return strings.Join(v.pre, ".")
+}
+
+// Metadata - return the build metadata of the version, "" if there is none
+func (v Version) Metadata() string {
+	return v.build
+}
+
+// isNumeric - check the string is all digits, without a sign
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isIdentifier - check the string is a non empty SemVer identifier, [0-9A-Za-z-]+
+func isIdentifier(s string) bool {
+	return badIdentifier(s) < 0
+}
+
+// badIdentifier - the byte offset of the first character that stops the
+//  string being a SemVer identifier, [0-9A-Za-z-]+, 0 if it is empty, or -1
+//  if it is an identifier
+func badIdentifier(s string) int {
+	if s == "" {
+		return 0
+	}
+	for i, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return i
+		}
+	}
+	return -1
+}
+
+// comparePrerelease - compare two lists of pre-release identifiers, as SemVer
+//  precedence, return 0 if they are equal, -1 if p1 < p2, or 1 if p1 > p2
+//  - no pre-release is greater than any pre-release, ie 1.0.0-rc.1 < 1.0.0
+//  - numeric identifiers compare as numbers, and are lower than alphanumeric ones
+//  - alphanumeric identifiers compare in ASCII order
+//  - if all identifiers are equal, the longer list is the greater
+func comparePrerelease(p1, p2 []string) int {
+	switch {
+	case len(p1) == 0 && len(p2) == 0:
+		return 0
+	case len(p1) == 0:
+		return 1
+	case len(p2) == 0:
+		return -1
+	}
+
+	for i, id1 := range p1 {
+		if i >= len(p2) {
+			return 1
+		}
+		id2 := p2[i]
+		num1, num2 := isNumeric(id1), isNumeric(id2)
+		switch {
+		case num1 && num2:
+			// no leading zeros, so the numbers compare as digits, which
+			//  also copes with numbers too big for an int
+			if c := compareDigits(id1, id2); c != 0 {
+				return c
+			}
+		case num1:
+			return -1
+		case num2:
+			return 1
+		}
+		if c := strings.Compare(id1, id2); c != 0 {
+			return c
+		}
+	}
+
+	if len(p1) < len(p2) {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,227 @@
+package version
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSemVer(t *testing.T) {
+	require := require.New(t)
+
+	// the valid and invalid examples from semver.org
+	valid := []string{
+		"0.0.4",
+		"1.2.3",
+		"10.20.30",
+		"1.1.2-prerelease+meta",
+		"1.1.2+meta",
+		"1.1.2+meta-valid",
+		"1.0.0-alpha",
+		"1.0.0-beta",
+		"1.0.0-alpha.beta",
+		"1.0.0-alpha.beta.1",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha0.valid",
+		"1.0.0-alpha.0valid",
+		"1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+		"1.0.0-rc.1+build.1",
+		"2.0.0-rc.1+build.123",
+		"1.2.3-beta",
+		"10.2.3-DEV-SNAPSHOT",
+		"1.2.3-SNAPSHOT-123",
+		"1.0.0",
+		"2.0.0",
+		"1.1.7",
+		"2.0.0+build.1848",
+		"2.0.1-alpha.1227",
+		"1.0.0-alpha+beta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+		"1.2.3----R-S.12.9.1--.12+meta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12",
+		"1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+		"1.0.0-0A.is.legal",
+		"99999999999999999999999.999999999999999999.99999999999999999",
+	}
+	for _, s := range valid {
+		v, err := NewSemVer(s)
+		require.NoError(err, "%q should be valid", s)
+		require.Equal(s, v.String(), "Version should have the expected string")
+	}
+
+	// levels too big for an int keep all their digits
+	v, err := NewSemVer("99999999999999999999999.999999999999999999.99999999999999999")
+	require.NoError(err)
+	major, err := v.PartDigits(0)
+	require.NoError(err)
+	require.Equal("99999999999999999999999", major, "Major should keep all its digits")
+	patch, err := v.PartDigits(2)
+	require.NoError(err)
+	require.Equal("99999999999999999", patch, "Patch should keep all its digits")
+
+	invalid := map[string]error{
+		"":                    InvalidVersion,
+		"1":                   InvalidSemVer,
+		"1.2":                 InvalidSemVer,
+		"1.2.3-0123":          InvalidPrerelease,
+		"1.2.3-0123.0123":     InvalidPrerelease,
+		"1.1.2+.123":          InvalidMetadata,
+		"+invalid":            InvalidSemVer,
+		"-invalid":            InvalidSemVer,
+		"-invalid+invalid":    InvalidSemVer,
+		"-invalid.01":         InvalidSemVer,
+		"alpha":               InvalidSemVer,
+		"alpha.beta":          InvalidSemVer,
+		"alpha.beta.1":        InvalidElement,
+		"alpha.1":             InvalidSemVer,
+		"alpha+beta":          InvalidSemVer,
+		"alpha_beta":          InvalidSemVer,
+		"alpha.":              InvalidSemVer,
+		"alpha..":             InvalidElement,
+		"beta":                InvalidSemVer,
+		"1.0.0-alpha_beta":    InvalidPrerelease,
+		"-alpha.":             InvalidSemVer,
+		"1.0.0-alpha..":       InvalidPrerelease,
+		"1.0.0-alpha..1":      InvalidPrerelease,
+		"1.0.0-alpha...1":     InvalidPrerelease,
+		"1.0.0-alpha....1":    InvalidPrerelease,
+		"1.0.0-alpha.....1":   InvalidPrerelease,
+		"1.0.0-alpha......1":  InvalidPrerelease,
+		"1.0.0-alpha.......1": InvalidPrerelease,
+		"01.1.1":              InvalidElement,
+		"1.01.1":              InvalidElement,
+		"1.1.01":              InvalidElement,
+		"1.2.3.DEV":           InvalidSemVer,
+		"1.2-SNAPSHOT":        InvalidSemVer,
+		"1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788": InvalidSemVer,
+		"1.2-RC-SNAPSHOT":          InvalidSemVer,
+		"-1.0.3-gamma+b7718":       InvalidSemVer,
+		"+justmeta":                InvalidSemVer,
+		"9.8.7+meta+meta":          InvalidMetadata,
+		"9.8.7-whatever+meta+meta": InvalidMetadata,
+		"+1.2.3":                   InvalidSemVer,
+		"1.2.3-":                   InvalidPrerelease,
+		"1.2.3+":                   InvalidMetadata,
+		"99999999999999999999999.999999999999999999.99999999999999999----RC-SNAPSHOT.12.09.1--------------------------------..12": InvalidPrerelease,
+	}
+	for s, want := range invalid {
+		v, err := NewSemVer(s)
+		require.Nil(v, "%q should not return a version", s)
+		require.ErrorIs(err, want, "%q should return the expected error", s)
+		var parseErr *ParseError
+		require.True(errors.As(err, &parseErr), "%q should return a ParseError", s)
+		require.Equal(s, parseErr.Input, "%q should be the ParseError input", s)
+	}
+}
+
+func TestSemVerParts(t *testing.T) {
+	require := require.New(t)
+
+	v, err := NewSemVer("1.2.3-rc.1+build.5")
+	require.NoError(err, "should create the version")
+	require.Equal(3, v.Len(), "should have 3 levels")
+	for i := 0; i < 3; i++ {
+		p, _ := v.Part(i)
+		require.Equal(i+1, p, "should return the expected level")
+	}
+	require.Equal("rc.1", v.Prerelease(), "should have the pre-release")
+	require.Equal("build.5", v.Metadata(), "should have the build metadata")
+
+	v, _ = NewSemVer("1.2.3")
+	require.Equal("", v.Prerelease(), "should have no pre-release")
+	require.Equal("", v.Metadata(), "should have no build metadata")
+
+	v, _ = NewSemVer("99999999999999999999999.2.3")
+	p, err := v.Part(0)
+	require.Equal(-1, p, "should not return a level too large for an int")
+	require.Equal(InvalidIntPart, err, "should return the expected error")
+	p, _ = v.Part(1)
+	require.Equal(2, p, "should return the other levels")
+}
+
+func TestSemVerPrecedence(t *testing.T) {
+	require := require.New(t)
+
+	// each is lower than the next, from semver.org section 11
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			v1, _ := NewSemVer(ordered[i])
+			v2, _ := NewSemVer(ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			require.Equal(want, v1.Compare(v2), "%s against %s", ordered[i], ordered[j])
+		}
+	}
+
+	testCases := map[string]struct {
+		v1   string
+		v2   string
+		rslt int
+	}{
+		"build metadata is ignored": {
+			v1:   "1.0.0+build.1",
+			v2:   "1.0.0+build.2",
+			rslt: 0,
+		},
+		"build metadata is ignored with a pre-release": {
+			v1:   "1.0.0-rc.1+a",
+			v2:   "1.0.0-rc.1",
+			rslt: 0,
+		},
+		"numeric lower than alphanumeric": {
+			v1:   "1.0.0-99",
+			v2:   "1.0.0-a",
+			rslt: -1,
+		},
+		"numbers too big for an int": {
+			v1:   "1.0.0-99999999999999999999",
+			v2:   "1.0.0-100000000000000000000",
+			rslt: -1,
+		},
+		"levels too big for an int": {
+			v1:   "99999999999999999999.0.0",
+			v2:   "100000000000000000000.0.0",
+			rslt: -1,
+		},
+		"ASCII order": {
+			v1:   "1.0.0-RC",
+			v2:   "1.0.0-rc",
+			rslt: -1,
+		},
+		"levels before pre-release": {
+			v1:   "1.0.1-alpha",
+			v2:   "1.0.0",
+			rslt: 1,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ver1, _ := NewSemVer(tc.v1)
+			require.NotNil(ver1, "should create Version")
+			ver2, _ := NewSemVer(tc.v2)
+			require.NotNil(ver2, "should create Version")
+
+			require.Equal(tc.rslt, ver1.Compare(ver2), "Compare should give the expected result")
+			require.Equal(-tc.rslt, ver2.Compare(ver1), "Compare should be symmetric")
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+var InvalidSpecifier = fmt.Errorf("Invalid PEP 440 specifier string")
+
+// SpecifierSet - a set of PEP 440 version specifiers, separated by ',',
+//  that a PEP440 version must all satisfy, eg ">=1.4, !=1.5.*, <2"
+//  - ~=1.4.5 is a compatible release, >=1.4.5, ==1.4.*
+//  - ==1.4 and !=1.4 ignore trailing zeros, and the local version of the
+//    version checked if the specifier has none. ==1.4.* and !=1.4.* match
+//    on the release levels
+//  - <, <=, >, >= order with PEP440.Compare, ignoring local versions,
+//    except <2.0 excludes pre-releases of 2.0, and >2.0 its post-releases
+//    and local versions
+//  - ===foo is arbitrary equality with the version string as given
+//  Pre-releases only satisfy the set if one of its ~=, ==, ===, >= or <=
+//  specifiers is for a pre-release. An empty set is satisfied by any
+//  version that is not a pre-release
+type SpecifierSet struct {
+	asString   string
+	specifiers []specifier
+	prerelease bool // whether pre-releases can satisfy the set
+}
+
+// specifier - a single PEP 440 version specifier
+type specifier struct {
+	asString string
+	op       string
+	ver      *PEP440
+	raw      string // the version as written, for ===
+	prefix   bool   // whether the specifier ends with .*, for == and !=
+}
+
+// NewSpecifierSet - create a SpecifierSet from a specifier string
+func NewSpecifierSet(s string) (*SpecifierSet, error) {
+	set := &SpecifierSet{asString: s}
+	if strings.TrimSpace(s) == "" {
+		return set, nil
+	}
+
+	for _, str := range strings.Split(s, ",") {
+		spec, err := parseSpecifier(strings.TrimSpace(str))
+		if err != nil {
+			return nil, err
+		}
+		set.specifiers = append(set.specifiers, spec)
+		switch spec.op {
+		case "~=", "==", "===", ">=", "<=":
+			if spec.ver != nil && spec.ver.IsPrerelease() {
+				set.prerelease = true
+			}
+		}
+	}
+	return set, nil
+}
+
+// parseSpecifier - parse one specifier, checking it is allowed its version
+func parseSpecifier(str string) (specifier, error) {
+	spec := specifier{asString: str}
+	for _, op := range []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(str, op) {
+			spec.op = op
+			break
+		}
+	}
+	if spec.op == "" {
+		return spec, fmt.Errorf("%w: %q has no operator", InvalidSpecifier, str)
+	}
+	spec.raw = strings.TrimSpace(str[len(spec.op):])
+	if spec.raw == "" || strings.IndexFunc(spec.raw, isSpace) >= 0 {
+		return spec, fmt.Errorf("%w: %q has no version", InvalidSpecifier, str)
+	}
+	if spec.op == "===" {
+		// arbitrary equality, the version need not be PEP 440
+		if ver, err := NewPEP440(spec.raw); err == nil {
+			spec.ver = ver
+		}
+		return spec, nil
+	}
+
+	verStr := spec.raw
+	if spec.op == "==" || spec.op == "!=" {
+		verStr = strings.TrimSuffix(verStr, ".*")
+		spec.prefix = verStr != spec.raw
+	}
+	ver, err := NewPEP440(verStr)
+	if err != nil {
+		return spec, fmt.Errorf("%w: %q: %v", InvalidSpecifier, str, err)
+	}
+	spec.ver = ver
+
+	switch {
+	case spec.prefix && ver.Normalized() != ver.BaseVersion():
+		return spec, fmt.Errorf("%w: %q can only use .* with a release", InvalidSpecifier, str)
+	case spec.op != "==" && spec.op != "!=" && len(ver.local) > 0:
+		return spec, fmt.Errorf("%w: %q cannot have a local version", InvalidSpecifier, str)
+	case spec.op == "~=" && len(ver.release) < 2:
+		return spec, fmt.Errorf("%w: %q needs at least 2 release levels", InvalidSpecifier, str)
+	}
+	return spec, nil
+}
+
+// String - print the specifier set as a string
+func (s SpecifierSet) String() string {
+	return s.asString
+}
+
+// Check - check if the version satisfies every specifier in the set
+func (s SpecifierSet) Check(p *PEP440) bool {
+	return s.Validate(p) == nil
+}
+
+// Validate - check if the version satisfies every specifier in the set. If
+//  it doesn't the error explains the first specifier it failed
+func (s SpecifierSet) Validate(p *PEP440) error {
+	if p.IsPrerelease() && !s.prerelease {
+		return fmt.Errorf("%w %q: %s is a pre-release", ConstraintNotMet, s.asString, p)
+	}
+	for _, spec := range s.specifiers {
+		if !spec.check(p) {
+			return fmt.Errorf("%w %q: %s does not match %s", ConstraintNotMet, s.asString, p, spec.asString)
+		}
+	}
+	return nil
+}
+
+// check - check if the version satisfies the specifier
+func (spec specifier) check(p *PEP440) bool {
+	public := p.public()
+	switch spec.op {
+	case "===":
+		return strings.EqualFold(strings.TrimSpace(p.asString), spec.raw)
+	case "~=":
+		prefix := *spec.ver
+		prefix.release = prefix.release[:len(prefix.release)-1]
+		return public.Compare(spec.ver) >= 0 && hasPrefix(p, &prefix)
+	case "==":
+		return spec.equal(p)
+	case "!=":
+		return !spec.equal(p)
+	case "<=":
+		return public.Compare(spec.ver) <= 0
+	case ">=":
+		return public.Compare(spec.ver) >= 0
+	case "<":
+		if !spec.ver.IsPrerelease() && p.IsPrerelease() && p.compareBase(spec.ver) == 0 {
+			return false
+		}
+		return public.Compare(spec.ver) < 0
+	case ">":
+		if !spec.ver.IsPostrelease() && p.IsPostrelease() && p.compareBase(spec.ver) == 0 {
+			return false
+		}
+		if len(p.local) > 0 && p.compareBase(spec.ver) == 0 {
+			return false
+		}
+		return public.Compare(spec.ver) > 0
+	}
+	return false
+}
+
+// equal - check if the version matches an == specifier
+func (spec specifier) equal(p *PEP440) bool {
+	if spec.prefix {
+		return hasPrefix(p, spec.ver)
+	}
+	if len(spec.ver.local) == 0 {
+		return p.public().Compare(spec.ver) == 0
+	}
+	return p.Compare(spec.ver) == 0
+}
+
+// hasPrefix - check if the version has the epoch and release levels of
+//  prefix, with missing levels of the version as 0, so 1.0 has prefix 1.0.0
+func hasPrefix(p, prefix *PEP440) bool {
+	if p.epoch != prefix.epoch {
+		return false
+	}
+	for i, val := range prefix.release {
+		level := 0
+		if i < len(p.release) {
+			level = p.release[i]
+		}
+		if level != val {
+			return false
+		}
+	}
+	return true
+}
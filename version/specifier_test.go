@@ -0,0 +1,154 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecifierSet(t *testing.T) {
+	require := require.New(t)
+
+	testCases := map[string]struct {
+		pass []string
+		fail []string
+	}{
+		"~=2.0": {
+			pass: []string{"2.0", "2.1", "2.9.9", "2.0.post1"},
+			fail: []string{"1.9", "3.0", "3.0.dev1", "2.1a1"},
+		},
+		"~=2.0.0": {
+			pass: []string{"2.0", "2.0.5"},
+			fail: []string{"2.1", "1.9"},
+		},
+		"~=2.2.post3": {
+			pass: []string{"2.2.post3", "2.3", "2.9"},
+			fail: []string{"2.2", "2.2.post2", "3.0"},
+		},
+		"~=1.4.5a4": {
+			pass: []string{"1.4.5a4", "1.4.5", "1.4.6", "1.4.6rc1"},
+			fail: []string{"1.4.5a3", "1.5"},
+		},
+		"==2": {
+			pass: []string{"2", "2.0", "2.0.0", "2.0+deadbeef"},
+			fail: []string{"2.0.1", "2.0.post1"},
+		},
+		"==2.0+deadbeef": {
+			pass: []string{"2.0+deadbeef"},
+			fail: []string{"2.0", "2.0+deadbeef.1"},
+		},
+		"==2.*": {
+			pass: []string{"2", "2.0", "2.5.1", "2.0.post1", "2.0+local"},
+			fail: []string{"1.9", "3.0", "20.0"},
+		},
+		"==1!2.*": {
+			pass: []string{"1!2.0"},
+			fail: []string{"2.0"},
+		},
+		"!=2.0": {
+			pass: []string{"2.1", "1.9", "2.0.post1"},
+			fail: []string{"2.0", "2.0.0", "2.0+local"},
+		},
+		"!=2.*": {
+			pass: []string{"3.0", "1.9"},
+			fail: []string{"2.0", "2.5"},
+		},
+		"<2.0": {
+			pass: []string{"1.9", "1.9.post1"},
+			fail: []string{"2.0", "2.0a1", "2.0.dev1", "2.1"},
+		},
+		"<2.0rc1": {
+			pass: []string{"1.9"},
+			fail: []string{"2.0b1", "2.0rc1", "2.0"},
+		},
+		">=2.0a1, <2.0rc1": {
+			pass: []string{"2.0a1", "2.0b1"},
+			fail: []string{"2.0.dev1", "2.0rc1", "2.0"},
+		},
+		"<=2.0": {
+			pass: []string{"2.0", "2.0+local", "1.0"},
+			fail: []string{"2.0.post1"},
+		},
+		">2.0": {
+			pass: []string{"2.1", "2.0.1", "3.0"},
+			fail: []string{"2.0", "2.0.post1", "2.0+local", "1.9"},
+		},
+		">2.0.post1": {
+			pass: []string{"2.0.post2", "2.1"},
+			fail: []string{"2.0.post1", "2.0"},
+		},
+		">=2.0": {
+			pass: []string{"2.0", "2.0+local", "2.0.post1", "3"},
+			fail: []string{"1.9", "2.0rc1"},
+		},
+		">=2.0.dev1": {
+			pass: []string{"2.0.dev1", "2.0a1", "2.0"},
+			fail: []string{"2.0.dev0", "1.9"},
+		},
+		"===1.0": {
+			pass: []string{"1.0"},
+			fail: []string{"1.0.0", "1.0+local"},
+		},
+		">=1.0, <2.0, !=1.5.*": {
+			pass: []string{"1.0", "1.4.9", "1.6"},
+			fail: []string{"0.9", "1.5", "1.5.1", "2.0"},
+		},
+		"": {
+			pass: []string{"0.1", "1!99"},
+			fail: []string{"1.0a1", "1.0.dev1"},
+		},
+	}
+
+	for str, tc := range testCases {
+		t.Run(str, func(t *testing.T) {
+			set, err := NewSpecifierSet(str)
+			require.NoError(err, "should create the specifier set")
+			require.Equal(str, set.String(), "SpecifierSet should have the expected string")
+
+			for _, s := range tc.pass {
+				p, err := NewPEP440(s)
+				require.NoError(err, "should create the version")
+				require.True(set.Check(p), "%s should satisfy %q", s, str)
+			}
+			for _, s := range tc.fail {
+				p, err := NewPEP440(s)
+				require.NoError(err, "should create the version")
+				require.False(set.Check(p), "%s should not satisfy %q", s, str)
+				require.ErrorIs(set.Validate(p), ConstraintNotMet, "%s should not satisfy %q", s, str)
+			}
+		})
+	}
+}
+
+func TestSpecifierSetExplain(t *testing.T) {
+	require := require.New(t)
+
+	set, _ := NewSpecifierSet(">=1.0, !=1.5.*")
+	p, _ := NewPEP440("1.5.2")
+	require.EqualError(set.Validate(p), `Version does not satisfy constraint ">=1.0, !=1.5.*": 1.5.2 does not match !=1.5.*`, "should explain the failure")
+	p, _ = NewPEP440("1.6rc1")
+	require.EqualError(set.Validate(p), `Version does not satisfy constraint ">=1.0, !=1.5.*": 1.6rc1 is a pre-release`, "should explain the failure")
+}
+
+func TestSpecifierSetErrors(t *testing.T) {
+	require := require.New(t)
+
+	for _, s := range []string{
+		"1.0",
+		">=",
+		">=1.0,",
+		"~=1",
+		"~=1.0+local",
+		">=1.0+local",
+		"==1.0a1.*",
+		"==1.0+local.*",
+		"==1.*.0",
+		">=1.0 1",
+		"=>1.0",
+		">=french toast",
+	} {
+		set, err := NewSpecifierSet(s)
+		require.Nil(set, "%q should not return a specifier set", s)
+		require.ErrorIs(err, InvalidSpecifier, "%q should return the expected error", s)
+	}
+}
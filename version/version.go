@@ -8,11 +8,18 @@ import (
 
 // Version - stores a version string for comparison
 // - Limits version string to be in the format: \d+[.\d+]*
-// - Version string can contain any number of levels
+// - Version string can contain any number of levels, each of any length
+// - A SemVer version, see NewSemVer, also has pre-release and build metadata
+// - Versions compare using the Policy set when they are created, Strict by default
 
 type Version struct {
 	asString string
-	asArray  []int
+	asArray  []string // the digits of each level, without leading zeros
+	pre      []string // pre-release identifiers, a SemVer version only
+	build    string   // build metadata, a SemVer version only
+	policy   Policy
+	levels   int // how many levels are significant, for the Significant policy
+	zeros    LeadingZeros
 }
 
 var (
@@ -20,33 +27,64 @@ var (
 	InvalidElement = fmt.Errorf("Invalid Version string, non-numeric element")
 	InvalidSeparatorUse = fmt.Errorf("Invalid Version string, possible missing element")
 	InvalidIndex = fmt.Errorf("Version does not contain requested element")
+	InvalidIntPart = fmt.Errorf("Version element is too large for an int")
+	InvalidSemVer = fmt.Errorf("Invalid SemVer string, must be major.minor.patch")
+	InvalidPrerelease = fmt.Errorf("Invalid SemVer string, bad pre-release identifier")
+	InvalidMetadata = fmt.Errorf("Invalid SemVer string, bad build metadata identifier")
 )
 
-func NewVersion(s string) (*Version, error) {
-	if s == "" {
-		return nil, InvalidVersion
-	}
-
+// NewVersion - create a Version from a version string, with options such as
+//  the comparison Policy. Levels can be any number of digits, and leading
+//  zeros are ignored unless the LeadingZeros option rejects them.
+//  A string that cannot be parsed returns a *ParseError, which is also the
+//  sentinel error for the problem, such as InvalidElement
+func NewVersion(s string, opts ...Option) (*Version, error) {
 	v := &Version{
 		asString: s,
-		asArray:  []int{},
 	}
-	strArray := strings.Split(s, ".")
+	if err := applyOptions(v, opts); err != nil {
+		return nil, err
+	}
 
-	for _, str := range strArray {
-		if str == "" {
-			return nil, InvalidSeparatorUse
-		}
-		val, err := strconv.Atoi(str)
-		if err != nil {
-			return nil, InvalidElement
-		}
-		v.asArray = append(v.asArray, val)
+	levels, err := lexLevels(s, v.zeros)
+	if err != nil {
+		return nil, err
 	}
+	v.asArray = levels
 
 	return v, nil
 }
 
+// fromDigits - create a Version from the digits of its levels, without
+//  leading zeros
+func fromDigits(levels []string) *Version {
+	return &Version{
+		asString: strings.Join(levels, "."),
+		asArray:  append([]string{}, levels...),
+	}
+}
+
+// trimZeros - the digits without leading zeros, "0" if they are all zero
+func trimZeros(digits string) string {
+	trimmed := strings.TrimLeft(digits, "0")
+	if trimmed == "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// compareDigits - compare two numbers held as digits without leading zeros,
+//  of any length, return 0 if they are equal, -1 if d1 < d2, or 1 if d1 > d2
+func compareDigits(d1, d2 string) int {
+	if len(d1) != len(d2) {
+		if len(d1) < len(d2) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(d1, d2)
+}
+
 // String - print the version as a string
 func (v Version) String() string {
 	return v.asString
@@ -58,10 +96,25 @@ func (v Version) Len() int {
 }
 
 // Part - return the part of the version for the given index.
-// If index doesn't exist it returns an error
+// If index doesn't exist, or the part is too large for an int, it returns an error
 func (v Version) Part(index int) (int, error) {
-	if index >= v.Len() {
-		return -1, InvalidIndex
+	digits, err := v.PartDigits(index)
+	if err != nil {
+		return -1, err
+	}
+	val, err := strconv.Atoi(digits)
+	if err != nil {
+		return -1, InvalidIntPart
+	}
+	return val, nil
+}
+
+// PartDigits - return the digits of the part of the version for the given
+// index, without leading zeros, for parts of any length.
+// If index doesn't exist it returns an error
+func (v Version) PartDigits(index int) (string, error) {
+	if index < 0 || index >= v.Len() {
+		return "", InvalidIndex
 	}
 	return v.asArray[index], nil
 }
@@ -94,30 +147,58 @@ func (v Version) Equal(v2 *Version) bool {
 //   Will compare up to the level where one version is greater or less than the other
 //   If one version string is longer than the other, and they are equal up to that level,
 //     the shorter version will be considered the lower, ie 1.1.1 < 1.1.1.0
+//     unless v has the ZeroPadded or Significant policy, when missing levels are 0
+//   Versions with the same levels are then ordered by pre-release, see comparePrerelease.
+//     Build metadata is ignored, ie 1.0.0+a == 1.0.0+b
+//   The policy of v is used, whatever the policy of v2, so to compare versions
+//     with different policies use a Comparator
 func (v Version) Compare(v2 *Version) int {
-	// short circuit compare if whole version strings match 
+	return v.compareAs(v2, v.policy, v.levels)
+}
+
+// compareAs - compare two versions as Compare does, using the given policy
+//  and significant levels rather than those of v
+func (v Version) compareAs(v2 *Version, policy Policy, levels int) int {
+	// short circuit compare if whole version strings match
 	if v.String() == v2.String() {
 		return 0
 	}
 
-	for i, val1 := range v.asArray {
-		val2, err := v2.Part(i)
-		if err != nil {
-			// if v2 does not have part i, it is the lower
-			return 1
+	n := v.Len()
+	if v2.Len() > n {
+		n = v2.Len()
+	}
+	if policy == Significant && levels < n {
+		n = levels
+	}
+
+	for i := 0; i < n; i++ {
+		if policy == Strict {
+			// so far versions are the same, so the longer version is the later
+			if i >= v2.Len() {
+				return 1
+			}
+			if i >= v.Len() {
+				return -1
+			}
 		}
-		if val1 < val2 {
-			return -1
-		} else if val1 > val2 {
-			return 1
+		// otherwise a missing level is 0
+		val1, val2 := "0", "0"
+		if i < v.Len() {
+			val1 = v.asArray[i]
+		}
+		if i < v2.Len() {
+			val2 = v2.asArray[i]
+		}
+		if c := compareDigits(val1, val2); c != 0 {
+			return c
 		}
 	}
 
-	// so far versions are the same, so if v2 is longer than v, it will be the later version
-	if v.Len() < v2.Len() {
-		return -1
+	if policy == Significant {
+		return 0
 	}
 
-	// if we get here, the versions match
-	return 0
+	// if we get here, the levels match, so only a pre-release can tell them apart
+	return comparePrerelease(v.pre, v2.pre)
 }
@@ -39,6 +39,28 @@ func TestNew(t *testing.T) {
 			ver: "",
 			err: InvalidVersion,
 		},
+		"error - negative": {
+			ver: "1.-1",
+			err: InvalidElement,
+		},
+		"error - plus sign": {
+			ver: "+1.0",
+			err: InvalidElement,
+		},
+		"error - space": {
+			ver: "1. 2",
+			err: InvalidElement,
+		},
+		"error - trailing newline": {
+			ver: "1.2\n",
+			err: InvalidElement,
+		},
+		"success, long level": {
+			ver: "1.20240101123045123456789",
+		},
+		"success, leading zeros": {
+			ver: "1.001",
+		},
 	}
 
 	for tn, tc := range testcases {
@@ -47,7 +69,7 @@ func TestNew(t *testing.T) {
 			if tc.err != nil {
 				require.Nil(v, "a version should not be returned")
 				require.Error(err, "should return error when expected")
-				require.Equal(tc.err, err, "should return the expected error")
+				require.ErrorIs(err, tc.err, "should return the expected error")
 			} else {
 				require.NotNil(v, "a Version should be returned")
 				require.Equal(tc.ver, v.String(), "Version should have the expected string")
@@ -107,6 +129,38 @@ func TestCompare(t *testing.T) {
 			gt: true,
 			eq: false,
 		},
+		"v1 leading zeros equal v2": {
+			v1: "1.01.1",
+			v2: "1.1.001",
+			rslt: 0,
+			lt: false,
+			gt: false,
+			eq: true,
+		},
+		"v1 long level less than v2": {
+			v1: "1.99999999999999999999",
+			v2: "1.100000000000000000000",
+			rslt: -1,
+			lt: true,
+			gt: false,
+			eq: false,
+		},
+		"v1 long level greater than v2": {
+			v1: "1.20240101123045123456789.2",
+			v2: "1.20240101123045123456789.1",
+			rslt: 1,
+			lt: false,
+			gt: true,
+			eq: false,
+		},
+		"v1 long level with leading zeros equal v2": {
+			v1: "000000000000000000000000000001.12345678901234567890",
+			v2: "1.0012345678901234567890",
+			rslt: 0,
+			lt: false,
+			gt: false,
+			eq: true,
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -139,4 +193,49 @@ func TestPart(t *testing.T) {
 			require.Equal(InvalidIndex, err, "should return the expected error")
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestPartLong(t *testing.T) {
+	require := require.New(t)
+
+	ver, _ := NewVersion("2.0099999999999999999999.3")
+
+	p, err := ver.Part(1)
+	require.Equal(InvalidIntPart, err, "should return error for a part too large for an int")
+	require.Equal(-1, p, "should return -1 for a part too large for an int")
+
+	d, err := ver.PartDigits(1)
+	require.NoError(err, "should return version part digits")
+	require.Equal("99999999999999999999", d, "should return the digits without leading zeros")
+
+	_, err = ver.PartDigits(3)
+	require.Equal(InvalidIndex, err, "should return error for invalid index")
+}
+
+func BenchmarkNewVersion(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewVersion("1.22.333.4444")
+	}
+}
+
+func BenchmarkNewVersionLong(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewVersion("1.20240101123045123456789.98765432109876543210")
+	}
+}
+
+func BenchmarkCompare(b *testing.B) {
+	ver1, _ := NewVersion("1.22.333.4444")
+	ver2, _ := NewVersion("1.22.333.4445")
+	for i := 0; i < b.N; i++ {
+		ver1.Compare(ver2)
+	}
+}
+
+func BenchmarkCompareLong(b *testing.B) {
+	ver1, _ := NewVersion("1.20240101123045123456789.98765432109876543210")
+	ver2, _ := NewVersion("1.20240101123045123456789.98765432109876543211")
+	for i := 0; i < b.N; i++ {
+		ver1.Compare(ver2)
+	}
+}